@@ -1,13 +1,19 @@
 package cmd
 
 import (
+	"flag"
 	"github.com/sayan-biswas/kubectl-tekton/internal/cmd/config"
+	remove "github.com/sayan-biswas/kubectl-tekton/internal/cmd/delete"
+	"github.com/sayan-biswas/kubectl-tekton/internal/cmd/events"
 	"github.com/sayan-biswas/kubectl-tekton/internal/cmd/get"
 	"github.com/sayan-biswas/kubectl-tekton/internal/cmd/logs"
+	"github.com/sayan-biswas/kubectl-tekton/internal/cmd/prune"
+	"github.com/sayan-biswas/kubectl-tekton/internal/cmd/restore"
 	"github.com/sayan-biswas/kubectl-tekton/internal/cmd/version"
 	"github.com/spf13/cobra"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 	"k8s.io/cli-runtime/pkg/genericiooptions"
+	"k8s.io/klog/v2"
 	"k8s.io/kubectl/pkg/cmd/util"
 	"k8s.io/kubectl/pkg/util/completion"
 	"os"
@@ -32,6 +38,11 @@ func Command() *cobra.Command {
 
 	cf.AddFlags(c.PersistentFlags())
 
+	// verbosity of the klog output, e.g. -v=2 shows the negotiated APIs
+	kf := flag.NewFlagSet("klog", flag.ContinueOnError)
+	klog.InitFlags(kf)
+	c.PersistentFlags().AddGoFlag(kf.Lookup("v"))
+
 	f := util.NewFactory(util.NewMatchVersionFlags(cf))
 
 	completion.SetFactoryForCompletion(f)
@@ -41,8 +52,10 @@ func Command() *cobra.Command {
 		config.Command(ios, f),
 		get.Command(ios, f),
 		logs.Command(ios, f),
-		// Delete command not supported
-		//remove.Command(ios, f),
+		events.Command(ios, f),
+		remove.Command(ios, f),
+		prune.Command(ios, f),
+		restore.Command(ios, f),
 		version.Command(ios),
 	)
 
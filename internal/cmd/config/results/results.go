@@ -42,6 +42,9 @@ var (
 
 		# Configure specific parameters non-interactively
 		kubectl tekton config results host="https://localhost:8080" token="test-token"
+
+		# Redact values matching a regular expression and params with matching names, in addition to the built-in patterns
+		kubectl tekton config results redact-pattern="ghp_[A-Za-z0-9]{36}" redact-params="*credential*,*key*" --no-prompt
 	`)
 )
 
@@ -3,43 +3,34 @@ package delete
 import (
 	"errors"
 	"fmt"
+	"github.com/sayan-biswas/kubectl-tekton/internal/cmd/flags"
 	"github.com/sayan-biswas/kubectl-tekton/internal/helper"
+	"github.com/sayan-biswas/kubectl-tekton/internal/printer"
 	"github.com/sayan-biswas/kubectl-tekton/internal/results/action"
 	"github.com/sayan-biswas/kubectl-tekton/internal/results/client"
 	"github.com/sayan-biswas/kubectl-tekton/internal/results/config"
 	"github.com/spf13/cobra"
-	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
-	"k8s.io/apimachinery/pkg/types"
-	"k8s.io/cli-runtime/pkg/genericclioptions"
 	"k8s.io/cli-runtime/pkg/genericiooptions"
 	"k8s.io/cli-runtime/pkg/printers"
 	"k8s.io/kubectl/pkg/cmd/util"
-	"k8s.io/kubectl/pkg/explain"
-	"k8s.io/kubectl/pkg/scheme"
 	"k8s.io/kubectl/pkg/util/i18n"
 	"k8s.io/kubectl/pkg/util/templates"
 )
 
 type Options struct {
-	PrintFlags  *genericclioptions.PrintFlags
-	PrintObject printers.ResourcePrinterFunc
-	ToPrinter   func(*meta.RESTMapping, *bool, bool, bool) (printers.ResourcePrinterFunc, error)
-
-	Namespace       string
-	Resource        string
-	Name            string
-	UID             string
-	Limit           int32
-	Labels          string
-	Annotations     string
-	Finalizers      string
-	OwnerReferences string
-	Filter          string
+	Namespace     string
+	AllNamespaces bool
+	Resource      string
+	Name          string
+	Limit         int32
 
 	Client     client.Client
-	RESTMapper meta.RESTMapper
+	Kinds      flags.KindFlags
+	Remove     flags.RemoveFlags
+	Selector   flags.SelectorFlags
+	TimeRanges flags.TimeRangeFlags
 
 	IOStreams *genericiooptions.IOStreams
 	Factory   util.Factory
@@ -52,10 +43,25 @@ var (
 		Delete resources from tekton results `))
 
 	example = templates.Examples(i18n.T(`
-		# Delete all resources (type) from a namespace
+		# Delete all resources (type) from a namespace. The records, logs and results to delete are printed for confirmation.
 		kubectl tekton delete pr -n default
 
-		# Delete limited resources from a namespace. By default only oldest 10 records will be deleted.
+		# Only print the records, logs and results which would be deleted
+		kubectl tekton delete pr -n default --status=failed --dry-run
+
+		# Delete without confirmation, e.g. in scripts
+		kubectl tekton delete pr test -n default --yes
+
+		# Delete resources by name across all namespaces
+		kubectl tekton delete pr test -A
+
+		# Delete with more concurrent requests and print a report of the deleted and failed resources
+		kubectl tekton delete pr -n default --status=failed --yes --parallelism 10 -o json
+
+		# Back up the records and logs to a file before deleting them, as newline-delimited JSON or a tar archive
+		kubectl tekton delete pr -n default --status=failed --backup backup.tar.gz
+
+		# Set the number of resources fetched per page while listing the resources to delete
 		kubectl tekton delete pr -n default --limit 20
 
 		# Delete resources by specifying name. Partial name can also be provided.
@@ -89,16 +95,24 @@ var (
 		# OwnerReferences filter can be used to find child resources.
 		kubectl tekton delete pr -n default --owner-references="name=parent-name"
 		
+		# Status flag selects items by friendly status names, e.g. failed or timed out runs.
+		kubectl tekton delete pr -n default --status=failed,timeout
+
+		# Time windows accept durations or RFC3339 timestamps, e.g. runs started in the last 6 hours.
+		kubectl tekton delete pr -n default --since 6h
+
+		# Time windows can be combined to select a range.
+		kubectl tekton delete pr -n default --created-after 2024-01-01T00:00:00Z --created-before 2d
+
+		# Resolve resource types without the tekton CRDs on the cluster, e.g. for archived history.
+		kubectl tekton delete pr -n default --offline-kinds
+
 		# Filter flag can be used to pass raw filter. Invalid syntax will cause error.
 		kubectl tekton delete pr -n default --filter="data.status.conditions[0].reason in ['Failed']"`))
 )
 
 func Command(s *genericiooptions.IOStreams, f util.Factory) *cobra.Command {
 	o := &Options{
-		PrintFlags: genericclioptions.
-			NewPrintFlags("").
-			WithTypeSetter(scheme.Scheme).
-			WithDefaultOutput("yaml"),
 		IOStreams: s,
 		Factory:   f,
 	}
@@ -113,35 +127,28 @@ func Command(s *genericiooptions.IOStreams, f util.Factory) *cobra.Command {
 		RunE:    o.Run,
 	}
 
-	o.PrintFlags.AddFlags(c)
-
-	c.Flags().Int32VarP(&o.Limit, "limit", "", 10, "Limit number or resource")
-	c.Flags().StringVarP(&o.UID, "uid", "", "", "UID to select unique item")
-	c.Flags().StringVarP(&o.Labels, "selector", "", "", "Filter items by labels")
-	c.Flags().StringVarP(&o.Labels, "labels", "", "", "Filter items by labels")
-	c.Flags().StringVarP(&o.Annotations, "annotations", "", "", "Filter items by annotations")
-	c.Flags().StringVarP(&o.Finalizers, "finalizers", "", "", "Filter items by finalizers")
-	c.Flags().StringVarP(&o.OwnerReferences, "owner-references", "", "", "Filter items by OwnerReferences")
-	c.Flags().StringVarP(&o.Filter, "filter", "", "", "Use a raw filter string")
+	o.Kinds.AddFlags(c.Flags())
+	c.Flags().BoolVarP(&o.AllNamespaces, "all-namespaces", "A", false, "Delete resources across all namespaces")
+	o.Remove.AddFlags(c.Flags())
+	c.Flags().Int32VarP(&o.Limit, "limit", "", 10, "Number of resources fetched per page")
+	o.Selector.AddFlags(c.Flags())
+	o.TimeRanges.AddFlags(c.Flags())
 
 	return c
 }
 
 // PreRun completes the required command-line options
 func (o *Options) PreRun(_ *cobra.Command, args []string) (err error) {
-	printer, err := o.PrintFlags.ToPrinter()
+	o.Namespace, _, err = o.Factory.ToRawKubeConfigLoader().Namespace()
 	if err != nil {
 		return err
 	}
-	o.PrintObject = printer.PrintObj
 
-	o.Namespace, _, err = o.Factory.ToRawKubeConfigLoader().Namespace()
-	if err != nil {
-		return err
+	if o.AllNamespaces {
+		o.Namespace = ""
 	}
 
-	o.RESTMapper, err = o.Factory.ToRESTMapper()
-	if err != nil {
+	if err := o.Kinds.Complete(o.Factory); err != nil {
 		return err
 	}
 
@@ -160,7 +167,7 @@ func (o *Options) PreRun(_ *cobra.Command, args []string) (err error) {
 		o.Name = args[1]
 	}
 
-	if o.Namespace == "" {
+	if o.Namespace == "" && !o.AllNamespaces {
 		return errors.New("namespace must be specified")
 	}
 
@@ -168,81 +175,117 @@ func (o *Options) PreRun(_ *cobra.Command, args []string) (err error) {
 		return errors.New("limit should be between 5 and 100")
 	}
 
+	if err := o.Remove.Validate(); err != nil {
+		return err
+	}
+
 	return nil
 }
 
-// Run performs the execution of 'config view' sub command
+// Run performs the execution of 'delete' sub command
 func (o *Options) Run(_ *cobra.Command, _ []string) error {
-	gvr, _, err := explain.SplitAndParseResourceRequest(o.Resource, o.RESTMapper)
+	gvks, err := o.Kinds.Parse(o.Resource)
 	if err != nil {
 		return err
 	}
 
-	gvk, err := o.RESTMapper.KindFor(gvr)
-	if err != nil {
-		return err
-	}
-
-	// TODO: Version override is not required after tekton results migration to V1 APIs
-	gvk.Version = "v1beta1"
-
-	v, k := gvk.ToAPIVersionAndKind()
-
 	opts := &action.Options{
-		Filter: o.Filter,
 		ListOptions: metav1.ListOptions{
-			TypeMeta: metav1.TypeMeta{
-				Kind:       k,
-				APIVersion: v,
-			},
 			Limit: int64(o.Limit),
 		},
 		ObjectMeta: metav1.ObjectMeta{
-			Name:            o.Name,
-			Namespace:       o.Namespace,
-			UID:             types.UID(o.UID),
-			Labels:          helper.ParseLabels(o.Labels),
-			Annotations:     helper.ParseAnnotations(o.Annotations),
-			Finalizers:      helper.ParseFinalizers(o.Finalizers),
-			OwnerReferences: helper.ParseOwnerReferences(o.OwnerReferences),
+			Name:      o.Name,
+			Namespace: o.Namespace,
 		},
 	}
 
-	n := 0
+	// records of older watchers are stored with v1beta1 versions of the kinds
+	opts.SetKinds(helper.StoredVersions(gvks))
+
+	o.Selector.Apply(opts)
+	if err := o.TimeRanges.Apply(opts); err != nil {
+		return err
+	}
+
+	ds, err := o.deletions(opts)
+	if err != nil {
+		return err
+	}
+	if len(ds) == 0 {
+		return printers.WriteEscaped(o.IOStreams.Out, "No resources found\n")
+	}
+
+	// the preview is printed to the error stream when a report is printed
+	out := o.Remove.Out(o.IOStreams)
+
+	if err := printer.PrintDeletions(out, ds, nil); err != nil {
+		return err
+	}
+
+	if o.Remove.DryRun {
+		fmt.Fprintf(out, "%d resource(s) would be deleted (dry run).\n", len(ds))
+		return nil
+	}
+
+	ok, err := o.Remove.Confirm(o.IOStreams, fmt.Sprintf("Delete %d resource(s)?", len(ds)))
+	if err != nil || !ok {
+		return err
+	}
+
+	return o.Remove.Remove(o.Client, o.IOStreams, ds, func(r *printer.DeletionReport) string {
+		return fmt.Sprintf("%d resource(s) deleted, %d failed.", r.Deleted, r.Failed)
+	})
+}
+
+// deletions lists the entries removed for all the matching runs, an entry
+// shared by multiple runs, like children of a deleted parent, is listed once.
+// Results are listed once all the records of the result are removed.
+func (o *Options) deletions(opts *action.Options) ([]action.Deletion, error) {
+	var ds []action.Deletion
+	seen := map[string]bool{}
 	for nextPage := true; nextPage; {
 		ul, err := action.List(o.Client, opts)
 		if err != nil {
-			return err
+			return nil, err
 		}
 
 		l := new(struct {
 			NextPageToken string `json:"nextPageToken,omitempty" yaml:"nextPageToken,omitempty"`
 			Items         []struct {
+				metav1.TypeMeta   `json:",inline"`
 				metav1.ObjectMeta `json:"metadata,omitempty"`
 			} `json:"items"`
 		})
 		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(ul.UnstructuredContent(), l); err != nil {
-			return err
+			return nil, err
 		}
 
-		// iterate through the list and delete items one by one
 		for _, item := range l.Items {
-			//
-			if err := action.Delete(o.Client, &action.Options{
+			rds, err := action.Deletions(o.Client, &action.Options{
+				ListOptions: metav1.ListOptions{
+					TypeMeta: item.TypeMeta,
+				},
 				ObjectMeta: metav1.ObjectMeta{
+					Name:        item.Name,
+					Namespace:   item.Namespace,
 					UID:         item.UID,
 					Annotations: item.Annotations,
 				},
-			}); err != nil {
-				return err
+			})
+			if err != nil {
+				return nil, err
+			}
+			for _, d := range rds {
+				if !seen[d.Name] {
+					seen[d.Name] = true
+					ds = append(ds, d)
+				}
 			}
-			n += 1
 		}
 		if nextPage = l.NextPageToken != ""; nextPage {
 			opts.ListOptions.Continue = l.NextPageToken
 		}
 	}
-
-	fmt.Fprintf(o.IOStreams.Out, "%d resource(s) deleted.\n", n)
-	return nil
+	// a result shared by multiple runs is only covered by all of them
+	return action.AddResults(o.Client, ds)
 }
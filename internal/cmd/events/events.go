@@ -0,0 +1,189 @@
+package events
+
+import (
+	"errors"
+	"fmt"
+	"github.com/sayan-biswas/kubectl-tekton/internal/cmd/flags"
+	"github.com/sayan-biswas/kubectl-tekton/internal/printer"
+	"github.com/sayan-biswas/kubectl-tekton/internal/results/action"
+	"github.com/sayan-biswas/kubectl-tekton/internal/results/client"
+	"github.com/sayan-biswas/kubectl-tekton/internal/results/config"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	"k8s.io/cli-runtime/pkg/printers"
+	"k8s.io/kubectl/pkg/cmd/util"
+	"k8s.io/kubectl/pkg/scheme"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+	"strings"
+)
+
+type Options struct {
+	PrintFlags *genericclioptions.PrintFlags
+
+	Namespace     string
+	AllNamespaces bool
+	Resource      string
+	Name          string
+	UID           string
+	Status        []string
+	NoHeaders     bool
+
+	Client client.Client
+	Kinds  flags.KindFlags
+
+	IOStreams *genericiooptions.IOStreams
+	Factory   util.Factory
+}
+
+var (
+	short = i18n.T(`Get resource events`)
+
+	long = templates.LongDesc(i18n.T(`
+		Display the kubernetes events of a resource from tekton results storage`))
+
+	example = templates.Examples(i18n.T(`
+		# Get events of a run from tekton results storage, e.g. after the run was pruned from the cluster
+		kubectl tekton events pr test
+		kubectl tekton events tr test
+
+		# Get events of a run without knowing its namespace
+		kubectl tekton events pr test -A
+
+		# Get events of the failed run when the name matches several runs
+		kubectl tekton events pr test --status=failed
+
+		# Get events for a particular run using UID
+		kubectl tekton events tr test --uid f27a6d83-21d3-4256-a8f0-0875b123895f
+
+		# Get events as yaml or json
+		kubectl tekton events pr test -o yaml`))
+)
+
+func Command(s *genericiooptions.IOStreams, f util.Factory) *cobra.Command {
+	o := &Options{
+		PrintFlags: genericclioptions.
+			NewPrintFlags("").
+			WithTypeSetter(scheme.Scheme),
+		IOStreams: s,
+		Factory:   f,
+	}
+
+	c := &cobra.Command{
+		Use:     "events [type] [name]",
+		Aliases: []string{"event", "ev"},
+		Short:   short,
+		Long:    long,
+		Example: example,
+		Args:    cobra.ExactArgs(2),
+		PreRunE: o.PreRun,
+		RunE:    o.Run,
+	}
+
+	o.PrintFlags.AddFlags(c)
+	o.Kinds.AddFlags(c.Flags())
+	c.Flags().BoolVarP(&o.AllNamespaces, "all-namespaces", "A", false, "Find resource across all namespaces")
+	c.Flags().StringVarP(&o.UID, "uid", "", "", "UID")
+	c.Flags().StringSliceVarP(&o.Status, "status", "", nil,
+		"Filter runs by status, one or more of "+strings.Join(action.Statuses, ", "))
+	c.Flags().BoolVarP(&o.NoHeaders, "no-headers", "", false, "Don't print headers")
+
+	return c
+}
+
+// PreRun completes the required command-line options
+func (o *Options) PreRun(_ *cobra.Command, args []string) (err error) {
+	o.Namespace, _, err = o.Factory.ToRawKubeConfigLoader().Namespace()
+	if err != nil {
+		return err
+	}
+
+	if o.AllNamespaces {
+		o.Namespace = ""
+	}
+
+	if err := o.Kinds.Complete(o.Factory); err != nil {
+		return err
+	}
+
+	c, err := config.NewConfig(o.Factory)
+	if err != nil {
+		return err
+	}
+
+	o.Client, err = client.NewClient(c.Get())
+	if err != nil {
+		return err
+	}
+
+	o.Resource = args[0]
+	o.Name = args[1]
+
+	if o.Namespace == "" && !o.AllNamespaces {
+		return errors.New("namespace must be specified")
+	}
+
+	return nil
+}
+
+// Run performs the execution of 'events' sub command
+func (o *Options) Run(_ *cobra.Command, _ []string) error {
+	gvks, err := o.Kinds.Parse(o.Resource)
+	if err != nil {
+		return err
+	}
+	if len(gvks) > 1 {
+		return errors.New("events can be fetched for only one resource type")
+	}
+	gvk := gvks[0]
+
+	v, k := gvk.ToAPIVersionAndKind()
+
+	opts := &action.Options{
+		Status: o.Status,
+		ListOptions: metav1.ListOptions{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       k,
+				APIVersion: v,
+			},
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      o.Name,
+			Namespace: o.Namespace,
+			UID:       types.UID(o.UID),
+		},
+	}
+
+	ul, err := action.List(o.Client, opts)
+	if err != nil {
+		return err
+	}
+
+	switch len(ul.Items) {
+	default:
+		return printers.WriteEscaped(o.IOStreams.Out,
+			fmt.Sprintf("Multiple %s found, narrow down with --uid flag.", gvk.Kind))
+	case 0:
+		return printers.WriteEscaped(o.IOStreams.Out, fmt.Sprintf("No %s found", gvk.Kind))
+	case 1:
+		break
+	}
+
+	el, err := action.Events(o.Client, &ul.Items[0])
+	if err != nil {
+		return err
+	}
+	if el == nil {
+		return printers.WriteEscaped(o.IOStreams.Out, "No events found")
+	}
+
+	if *o.PrintFlags.OutputFormat != "" {
+		return printer.PrintObject(o.IOStreams.Out, el, o.PrintFlags)
+	}
+	return printer.PrintEvents(o.IOStreams.Out, el.Items, &printer.Options{
+		NoHeaders: o.NoHeaders,
+	})
+}
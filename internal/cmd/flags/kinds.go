@@ -0,0 +1,36 @@
+package flags
+
+import (
+	"github.com/sayan-biswas/kubectl-tekton/internal/helper"
+	"github.com/spf13/pflag"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+// KindFlags resolves resource types with the cluster, or only with the
+// built-in tekton kinds when the kinds are offline.
+type KindFlags struct {
+	Offline    bool
+	RESTMapper meta.RESTMapper
+}
+
+// AddFlags registers the flag selecting the built-in tekton kinds.
+func (f *KindFlags) AddFlags(fs *pflag.FlagSet) {
+	fs.BoolVarP(&f.Offline, "offline-kinds", "", false,
+		"Resolve resource types from built-in tekton kinds instead of the cluster")
+}
+
+// Complete gets the mapper of the cluster, unless the kinds are offline.
+func (f *KindFlags) Complete(g genericclioptions.RESTClientGetter) (err error) {
+	if f.Offline {
+		return nil
+	}
+	f.RESTMapper, err = g.ToRESTMapper()
+	return err
+}
+
+// Parse resolves comma separated resource types to kinds.
+func (f *KindFlags) Parse(s string) ([]schema.GroupVersionKind, error) {
+	return helper.ParseResources(s, f.RESTMapper)
+}
@@ -0,0 +1,115 @@
+package flags
+
+import (
+	"errors"
+	"fmt"
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/AlecAivazis/survey/v2/terminal"
+	"github.com/sayan-biswas/kubectl-tekton/internal/printer"
+	"github.com/sayan-biswas/kubectl-tekton/internal/results/action"
+	"github.com/sayan-biswas/kubectl-tekton/internal/results/client"
+	"github.com/spf13/pflag"
+	"golang.org/x/term"
+	"io"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+)
+
+// RemoveFlags control how records, logs and results are deleted: whether to
+// ask first, how many are deleted concurrently, the backup taken before and
+// the report printed after deleting them.
+type RemoveFlags struct {
+	DryRun      bool
+	Yes         bool
+	Parallelism int
+	Output      string
+	Backup      string
+}
+
+// AddFlags registers the flags of the deletion.
+func (f *RemoveFlags) AddFlags(fs *pflag.FlagSet) {
+	fs.BoolVarP(&f.DryRun, "dry-run", "", false, "Only print the records, logs and results which would be deleted")
+	fs.BoolVarP(&f.Yes, "yes", "y", false, "Delete without confirmation")
+	fs.IntVarP(&f.Parallelism, "parallelism", "", 5, "Number of resources deleted concurrently")
+	fs.StringVarP(&f.Output, "output", "o", "",
+		"Print a report of the deleted and failed resources, one of json or yaml")
+	fs.StringVarP(&f.Backup, "backup", "", "",
+		"Back up the records and logs to a file before deleting them, as newline-delimited JSON or a .tar or .tar.gz archive")
+}
+
+// Validate checks the values of the flags.
+func (f *RemoveFlags) Validate() error {
+	if f.Parallelism < 1 {
+		return errors.New("parallelism should be at least 1")
+	}
+	if f.Output != "" && f.Output != printer.ReportJSON && f.Output != printer.ReportYAML {
+		return fmt.Errorf("output should be one of %s or %s", printer.ReportJSON, printer.ReportYAML)
+	}
+	return nil
+}
+
+// Out returns the stream of the previews and summaries, which is the error
+// stream when a report is printed.
+func (f *RemoveFlags) Out(s *genericiooptions.IOStreams) io.Writer {
+	if f.Output != "" {
+		return s.ErrOut
+	}
+	return s.Out
+}
+
+// Confirm asks for confirmation before deleting, unless it's given with --yes.
+// Asking requires the input and error streams to be a terminal.
+func (f *RemoveFlags) Confirm(s *genericiooptions.IOStreams, message string) (bool, error) {
+	if f.Yes {
+		return true, nil
+	}
+	in, ok := s.In.(terminal.FileReader)
+	if !ok || !term.IsTerminal(int(in.Fd())) {
+		return false, errors.New("confirmation requires a terminal, use --yes to delete without confirmation")
+	}
+	out, ok := s.ErrOut.(terminal.FileWriter)
+	if !ok {
+		return false, errors.New("confirmation requires a terminal, use --yes to delete without confirmation")
+	}
+	ok = false
+	err := survey.AskOne(&survey.Confirm{Message: message}, &ok, survey.WithStdio(in, out, s.ErrOut))
+	return ok, err
+}
+
+// Remove deletes the entries, backing them up first when a backup is given,
+// and prints the summary and the report of the deletions. The report is
+// printed even when the backup fails to be closed, as the entries are already
+// committed to the backup when they are deleted.
+func (f *RemoveFlags) Remove(c client.Client, s *genericiooptions.IOStreams, ds []action.Deletion,
+	summary func(r *printer.DeletionReport) string) (err error) {
+	ro := &action.RemoveOptions{
+		Parallelism: f.Parallelism,
+		Progress:    printer.Progress(s.ErrOut, "Deleting"),
+	}
+	if f.Backup != "" {
+		if ro.Backup, err = action.NewBackupWriter(f.Backup); err != nil {
+			return err
+		}
+	}
+
+	r := printer.NewDeletionReport(action.RemoveAll(c, ds, ro))
+
+	var errs []error
+	if ro.Backup != nil {
+		if err := ro.Backup.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("failed to close backup: %w", err))
+		} else {
+			fmt.Fprintf(f.Out(s), "Backup written to %s\n", f.Backup)
+		}
+	}
+
+	if f.Output == "" {
+		fmt.Fprintln(s.Out, summary(r))
+	}
+	if err := printer.PrintDeletionReport(s.Out, r, f.Output, nil); err != nil {
+		return err
+	}
+	if r.Failed > 0 {
+		errs = append(errs, fmt.Errorf("%d resource(s) failed to be deleted", r.Failed))
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,40 @@
+package flags
+
+import (
+	"bytes"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	"strings"
+	"testing"
+)
+
+func TestRemoveFlagsValidate(t *testing.T) {
+	tests := []struct {
+		f       RemoveFlags
+		wantErr bool
+	}{
+		{RemoveFlags{Parallelism: 5}, false},
+		{RemoveFlags{Parallelism: 1, Output: "json"}, false},
+		{RemoveFlags{Parallelism: 0}, true},
+		{RemoveFlags{Parallelism: 5, Output: "table"}, true},
+	}
+	for _, tt := range tests {
+		if err := tt.f.Validate(); (err != nil) != tt.wantErr {
+			t.Errorf("Validate(%+v) = %v, want error %t", tt.f, err, tt.wantErr)
+		}
+	}
+}
+
+func TestRemoveFlagsConfirm(t *testing.T) {
+	s := &genericiooptions.IOStreams{In: strings.NewReader("y\n"), Out: &bytes.Buffer{}, ErrOut: &bytes.Buffer{}}
+
+	f := RemoveFlags{Yes: true}
+	if ok, err := f.Confirm(s, "Delete?"); !ok || err != nil {
+		t.Errorf("Confirm() with yes = %t, %v", ok, err)
+	}
+
+	// the streams of the command are asked, which are not a terminal here
+	f = RemoveFlags{}
+	if ok, err := f.Confirm(s, "Delete?"); ok || err == nil {
+		t.Errorf("Confirm() without a terminal = %t, %v", ok, err)
+	}
+}
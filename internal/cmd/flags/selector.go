@@ -0,0 +1,44 @@
+package flags
+
+import (
+	"github.com/sayan-biswas/kubectl-tekton/internal/helper"
+	"github.com/sayan-biswas/kubectl-tekton/internal/results/action"
+	"github.com/spf13/pflag"
+	"k8s.io/apimachinery/pkg/types"
+	"strings"
+)
+
+// SelectorFlags select items by their metadata, status or a raw filter.
+type SelectorFlags struct {
+	UID             string
+	Labels          string
+	Annotations     string
+	Finalizers      string
+	OwnerReferences string
+	Filter          string
+	Status          []string
+}
+
+// AddFlags registers the selector flags.
+func (f *SelectorFlags) AddFlags(fs *pflag.FlagSet) {
+	fs.StringVarP(&f.UID, "uid", "", "", "UID to select unique item")
+	fs.StringVarP(&f.Labels, "selector", "", "", "Filter items by labels")
+	fs.StringVarP(&f.Labels, "labels", "", "", "Filter items by labels")
+	fs.StringVarP(&f.Annotations, "annotations", "", "", "Filter items by annotations")
+	fs.StringVarP(&f.Finalizers, "finalizers", "", "", "Filter items by finalizers")
+	fs.StringVarP(&f.OwnerReferences, "owner-references", "", "", "Filter items by OwnerReferences")
+	fs.StringVarP(&f.Filter, "filter", "", "", "Use a raw filter string")
+	fs.StringSliceVarP(&f.Status, "status", "", nil,
+		"Filter items by status, one or more of "+strings.Join(action.Statuses, ", "))
+}
+
+// Apply sets the selectors on the action options.
+func (f *SelectorFlags) Apply(opts *action.Options) {
+	opts.Filter = f.Filter
+	opts.Status = f.Status
+	opts.UID = types.UID(f.UID)
+	opts.Labels = helper.ParseLabels(f.Labels)
+	opts.Annotations = helper.ParseAnnotations(f.Annotations)
+	opts.Finalizers = helper.ParseFinalizers(f.Finalizers)
+	opts.OwnerReferences = helper.ParseOwnerReferences(f.OwnerReferences)
+}
@@ -0,0 +1,41 @@
+package flags
+
+import (
+	"github.com/sayan-biswas/kubectl-tekton/internal/results/action"
+	"github.com/spf13/pflag"
+	"reflect"
+	"testing"
+)
+
+func TestSelectorFlags(t *testing.T) {
+	var f SelectorFlags
+	fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	f.AddFlags(fs)
+	if err := fs.Parse([]string{
+		"--uid", "f27a6d83",
+		"--selector", "app=web",
+		"--annotations", "chains",
+		"--filter", `data.metadata.name.startsWith("build")`,
+		"--status", "failed,timeout",
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	opts := &action.Options{}
+	f.Apply(opts)
+	if opts.UID != "f27a6d83" {
+		t.Errorf("UID = %q", opts.UID)
+	}
+	if want := map[string]string{"app": "web"}; !reflect.DeepEqual(opts.Labels, want) {
+		t.Errorf("Labels = %v, want %v", opts.Labels, want)
+	}
+	if want := map[string]string{"chains": ""}; !reflect.DeepEqual(opts.Annotations, want) {
+		t.Errorf("Annotations = %v, want %v", opts.Annotations, want)
+	}
+	if opts.Filter != `data.metadata.name.startsWith("build")` {
+		t.Errorf("Filter = %q", opts.Filter)
+	}
+	if want := []string{"failed", "timeout"}; !reflect.DeepEqual(opts.Status, want) {
+		t.Errorf("Status = %v, want %v", opts.Status, want)
+	}
+}
@@ -0,0 +1,55 @@
+package flags
+
+import (
+	"github.com/sayan-biswas/kubectl-tekton/internal/helper"
+	"github.com/sayan-biswas/kubectl-tekton/internal/results/action"
+	"github.com/spf13/pflag"
+)
+
+// TimeRangeFlags select items by time windows of their timestamps. Times are
+// durations before now (e.g. 6h, 2d), dates or RFC3339 timestamps.
+type TimeRangeFlags struct {
+	Since           string
+	Until           string
+	CreatedAfter    string
+	CreatedBefore   string
+	UpdatedAfter    string
+	UpdatedBefore   string
+	CompletedAfter  string
+	CompletedBefore string
+}
+
+// AddFlags registers the time window flags.
+func (f *TimeRangeFlags) AddFlags(fs *pflag.FlagSet) {
+	fs.StringVarP(&f.Since, "since", "", "", "Filter items started after a duration (e.g. 6h, 2d) or RFC3339 time")
+	fs.StringVarP(&f.Until, "until", "", "", "Filter items started before a duration (e.g. 6h, 2d) or RFC3339 time")
+	fs.StringVarP(&f.CreatedAfter, "created-after", "", "", "Filter items created after a duration or RFC3339 time")
+	fs.StringVarP(&f.CreatedBefore, "created-before", "", "", "Filter items created before a duration or RFC3339 time")
+	fs.StringVarP(&f.UpdatedAfter, "updated-after", "", "", "Filter items updated after a duration or RFC3339 time")
+	fs.StringVarP(&f.UpdatedBefore, "updated-before", "", "", "Filter items updated before a duration or RFC3339 time")
+	fs.StringVarP(&f.CompletedAfter, "completed-after", "", "", "Filter items completed after a duration or RFC3339 time")
+	fs.StringVarP(&f.CompletedBefore, "completed-before", "", "", "Filter items completed before a duration or RFC3339 time")
+}
+
+// Apply parses the time windows into the action options.
+func (f *TimeRangeFlags) Apply(opts *action.Options) (err error) {
+	ranges := []struct {
+		*action.TimeRange
+		after  string
+		before string
+	}{
+		{&opts.Started, f.Since, f.Until},
+		{&opts.Created, f.CreatedAfter, f.CreatedBefore},
+		{&opts.Updated, f.UpdatedAfter, f.UpdatedBefore},
+		{&opts.Completed, f.CompletedAfter, f.CompletedBefore},
+	}
+	for _, r := range ranges {
+		if r.After, err = helper.ParseTime(r.after); err != nil {
+			return err
+		}
+		if r.Before, err = helper.ParseTime(r.before); err != nil {
+			return err
+		}
+	}
+	return nil
+}
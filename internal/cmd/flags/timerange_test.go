@@ -0,0 +1,51 @@
+package flags
+
+import (
+	"github.com/sayan-biswas/kubectl-tekton/internal/results/action"
+	"github.com/spf13/pflag"
+	"testing"
+)
+
+func TestTimeRangeFlags(t *testing.T) {
+	var f TimeRangeFlags
+	fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	f.AddFlags(fs)
+	if err := fs.Parse([]string{
+		"--since", "2024-05-01",
+		"--created-before", "2024-05-02T00:00:00Z",
+		"--completed-after", "1d",
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	opts := &action.Options{}
+	if err := f.Apply(opts); err != nil {
+		t.Fatal(err)
+	}
+	set := map[string]bool{
+		"started after":    opts.Started.After != nil,
+		"started before":   opts.Started.Before != nil,
+		"created after":    opts.Created.After != nil,
+		"created before":   opts.Created.Before != nil,
+		"updated after":    opts.Updated.After != nil,
+		"updated before":   opts.Updated.Before != nil,
+		"completed after":  opts.Completed.After != nil,
+		"completed before": opts.Completed.Before != nil,
+	}
+	for _, r := range []string{"started after", "created before", "completed after"} {
+		if !set[r] {
+			t.Errorf("%s is not set", r)
+		}
+		delete(set, r)
+	}
+	for r, ok := range set {
+		if ok {
+			t.Errorf("%s is set", r)
+		}
+	}
+
+	f.Until = "soon"
+	if err := f.Apply(&action.Options{}); err == nil {
+		t.Error("Apply() with an invalid time succeeded")
+	}
+}
@@ -2,7 +2,8 @@ package get
 
 import (
 	"errors"
-	"github.com/sayan-biswas/kubectl-tekton/internal/helper"
+	"fmt"
+	"github.com/sayan-biswas/kubectl-tekton/internal/cmd/flags"
 	"github.com/sayan-biswas/kubectl-tekton/internal/printer"
 	"github.com/sayan-biswas/kubectl-tekton/internal/results/action"
 	"github.com/sayan-biswas/kubectl-tekton/internal/results/client"
@@ -11,17 +12,19 @@ import (
 	"golang.org/x/term"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
-	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 	"k8s.io/cli-runtime/pkg/genericiooptions"
 	"k8s.io/cli-runtime/pkg/printers"
+	cmdget "k8s.io/kubectl/pkg/cmd/get"
 	"k8s.io/kubectl/pkg/cmd/util"
-	"k8s.io/kubectl/pkg/explain"
 	"k8s.io/kubectl/pkg/scheme"
 	"k8s.io/kubectl/pkg/util/i18n"
 	"k8s.io/kubectl/pkg/util/templates"
 	"os"
+	"strings"
+	"time"
 )
 
 const (
@@ -29,24 +32,32 @@ const (
 )
 
 type Options struct {
-	PrintFlags  *genericclioptions.PrintFlags
-	PrintObject printers.ResourcePrinterFunc
-	ToPrinter   func(*meta.RESTMapping, *bool, bool, bool) (printers.ResourcePrinterFunc, error)
-
-	Namespace       string
-	Resource        string
-	Name            string
-	UID             string
-	Limit           int32
-	SinglePage      bool
-	Labels          string
-	Annotations     string
-	Finalizers      string
-	OwnerReferences string
-	Filter          string
+	PrintFlags         *genericclioptions.PrintFlags
+	CustomColumnsFlags *cmdget.CustomColumnsPrintFlags
+	PrintObject        printers.ResourcePrinterFunc
+	ToPrinter          func(*meta.RESTMapping, *bool, bool, bool) (printers.ResourcePrinterFunc, error)
+	NoHeaders          bool
+	NoRedact           bool
+	Redactor           *printer.Redactor
+
+	Namespace     string
+	AllNamespaces bool
+	Resource      string
+	Name          string
+	Limit         int32
+	MaxItems      int32
+	All           bool
+	Continue      string
+	SinglePage    bool
+	Watch         bool
+	WatchInterval time.Duration
+	SortBy        string
+	Order         string
 
 	Client     client.Client
-	RESTMapper meta.RESTMapper
+	Kinds      flags.KindFlags
+	Selector   flags.SelectorFlags
+	TimeRanges flags.TimeRangeFlags
 
 	IOStreams *genericiooptions.IOStreams
 	Factory   util.Factory
@@ -62,9 +73,27 @@ var (
 		# List resources from a namespace
 		kubectl tekton get pr -n default
 
+		# List resources across all namespaces
+		kubectl tekton get pr -A
+
+		# List multiple types of resources, grouped by type.
+		kubectl tekton get pr,tr -n default
+
+		# List all types of runs.
+		kubectl tekton get all -n default
+
 		# List limited resources from a namespace. By default only 10 resources are listed.
 		kubectl tekton get pr -n default --limit 20
 
+		# List all pages without prompting, e.g. in scripts. Prompts are also disabled without a terminal.
+		kubectl tekton get pr -n default --all
+
+		# List at most 250 items, independent of the page size.
+		kubectl tekton get pr -n default --all --max-items 250
+
+		# Resume listing from the page token printed by a previous command.
+		kubectl tekton get pr -n default --continue="<token>"
+
 		# Get resources by specifying name. Partial name can also be provided.
 		kubectl tekton get pr test -n default
 
@@ -96,8 +125,45 @@ var (
 		# OwnerReferences filter can be used to find child resources.
 		kubectl tekton get pr -n default --owner-references="name=parent-name"
 		
+		# Sort items by a key, in ascending order to find the oldest runs.
+		kubectl tekton get pr -n default --sort-by=created --order=asc
+
+		# Sorting by duration, name, start or completion time fetches all the pages before printing.
+		kubectl tekton get pr -n default --sort-by=duration
+
+		# Status flag selects items by friendly status names, e.g. failed or timed out runs.
+		kubectl tekton get pr -n default --status=failed,timeout
+
+		# Time windows accept durations or RFC3339 timestamps, e.g. runs started in the last 6 hours.
+		kubectl tekton get pr -n default --since 6h
+
+		# Time windows can be combined to select a range.
+		kubectl tekton get pr -n default --created-after 2024-01-01T00:00:00Z --created-before 2d
+
+		# List resources with additional columns specific to the kind.
+		kubectl tekton get pr -n default -o wide
+
+		# Watch for new and updated resources, press CTRL+C to exit.
+		kubectl tekton get pr -n default --watch
+
+		# Watch starting from an update time, printing all the resources updated since.
+		kubectl tekton get pr -n default --watch --updated-after 1h
+
+		# Print all matched items as a list in JSON, e.g. to process with jq.
+		kubectl tekton get pr -n default --all -o json
+
+		# Print selected fields with jsonpath, go-template or custom-columns.
+		kubectl tekton get pr -n default -o jsonpath='{.items[*].metadata.name}'
+		kubectl tekton get pr -n default -o custom-columns=NAME:.metadata.name,REASON:.status.conditions[0].reason
+
+		# Resolve resource types without the tekton CRDs on the cluster, e.g. for archived history.
+		kubectl tekton get pr -n default --offline-kinds
+
 		# Filter flag can be used to pass raw filter. Invalid syntax will cause error.
-		kubectl tekton get pr -n default --filter="data.status.conditions[0].reason in ['Failed']"`))
+		kubectl tekton get pr -n default --filter="data.status.conditions[0].reason in ['Failed']"
+
+		# Secrets in printed objects, like params named *token* or *password*, are masked unless disabled.
+		kubectl tekton get pr test -n default -o yaml --no-redact`))
 )
 
 func Command(s *genericiooptions.IOStreams, f util.Factory) *cobra.Command {
@@ -106,12 +172,13 @@ func Command(s *genericiooptions.IOStreams, f util.Factory) *cobra.Command {
 			NewPrintFlags("").
 			WithTypeSetter(scheme.Scheme).
 			WithDefaultOutput("yaml"),
-		IOStreams: s,
-		Factory:   f,
+		CustomColumnsFlags: cmdget.NewCustomColumnsPrintFlags(),
+		IOStreams:          s,
+		Factory:            f,
 	}
 
 	c := &cobra.Command{
-		Use:     "get [type] [name]",
+		Use:     "get [type[,type...]|all] [name]",
 		Short:   short,
 		Long:    long,
 		Example: example,
@@ -121,35 +188,51 @@ func Command(s *genericiooptions.IOStreams, f util.Factory) *cobra.Command {
 	}
 
 	o.PrintFlags.AddFlags(c)
+	o.CustomColumnsFlags.AddFlags(c)
+	c.Flags().Lookup("output").Usage = fmt.Sprintf("Output format. One of: (%s).", strings.Join(append(
+		o.PrintFlags.AllowedFormats(), append(o.CustomColumnsFlags.AllowedFormats(), "wide")...), ", "))
+	c.Flags().BoolVar(&o.NoHeaders, "no-headers", false, "Don't print headers in table and custom-columns output")
 
+	o.Kinds.AddFlags(c.Flags())
+	c.Flags().BoolVarP(&o.AllNamespaces, "all-namespaces", "A", false, "List resources across all namespaces")
 	c.Flags().Int32VarP(&o.Limit, "limit", "", 10, "Limit number or resource")
 	c.Flags().BoolVar(&o.SinglePage, "single-page", false, "Output first page of results and immediately exit")
-	c.Flags().StringVarP(&o.UID, "uid", "", "", "UID to select unique item")
-	c.Flags().StringVarP(&o.Labels, "selector", "", "", "Filter items by labels")
-	c.Flags().StringVarP(&o.Labels, "labels", "", "", "Filter items by labels")
-	c.Flags().StringVarP(&o.Annotations, "annotations", "", "", "Filter items by annotations")
-	c.Flags().StringVarP(&o.Finalizers, "finalizers", "", "", "Filter items by finalizers")
-	c.Flags().StringVarP(&o.OwnerReferences, "owner-references", "", "", "Filter items by OwnerReferences")
-	c.Flags().StringVarP(&o.Filter, "filter", "", "", "Use a raw filter string")
+	c.Flags().BoolVarP(&o.Watch, "watch", "w", false, "After listing, watch for new or updated items")
+	c.Flags().DurationVar(&o.WatchInterval, "watch-interval", 5*time.Second, "Interval between queries in watch mode")
+	c.Flags().BoolVar(&o.All, "all", false, "Output all pages of results without prompting")
+	c.Flags().Int32VarP(&o.MaxItems, "max-items", "", 0, "Maximum number of items to output across pages")
+	c.Flags().StringVarP(&o.Continue, "continue", "", "", "Page token to continue listing from")
+	o.Selector.AddFlags(c.Flags())
+	c.Flags().StringVarP(&o.SortBy, "sort-by", "", "",
+		"Sort items by one of "+strings.Join(action.SortKeys, ", ")+" (default updated)")
+	c.Flags().StringVarP(&o.Order, "order", "", "desc", "Sort order, one of asc or desc")
+	o.TimeRanges.AddFlags(c.Flags())
+
+	c.Flags().BoolVarP(&o.NoRedact, "no-redact", "", false, "Don't mask secrets in the printed objects")
 
 	return c
 }
 
 // PreRun completes the required command-line options
 func (o *Options) PreRun(_ *cobra.Command, args []string) (err error) {
-	printer, err := o.PrintFlags.ToPrinter()
-	if err != nil {
-		return err
+	if !o.wide() {
+		printer, err := o.toPrinter()
+		if err != nil {
+			return err
+		}
+		o.PrintObject = printer.PrintObj
 	}
-	o.PrintObject = printer.PrintObj
 
 	o.Namespace, _, err = o.Factory.ToRawKubeConfigLoader().Namespace()
 	if err != nil {
 		return err
 	}
 
-	o.RESTMapper, err = o.Factory.ToRESTMapper()
-	if err != nil {
+	if o.AllNamespaces {
+		o.Namespace = ""
+	}
+
+	if err := o.Kinds.Complete(o.Factory); err != nil {
 		return err
 	}
 
@@ -163,12 +246,24 @@ func (o *Options) PreRun(_ *cobra.Command, args []string) (err error) {
 		return err
 	}
 
+	if !o.NoRedact {
+		e := c.GetExtension()
+		o.Redactor, err = printer.NewRedactor(e.RedactPattern, e.RedactParams)
+		if err != nil {
+			return err
+		}
+	}
+
 	o.Resource = args[0]
 	if len(args) > 1 {
 		o.Name = args[1]
 	}
 
-	if o.Namespace == "" {
+	if o.Redactor != nil && o.PrintObject != nil {
+		o.PrintObject = o.Redactor.Printer(o.PrintObject)
+	}
+
+	if o.Namespace == "" && !o.AllNamespaces {
 		return errors.New("namespace must be specified")
 	}
 
@@ -176,17 +271,28 @@ func (o *Options) PreRun(_ *cobra.Command, args []string) (err error) {
 		return errors.New("limit should be between 5 and 100")
 	}
 
+	if o.WatchInterval < time.Second {
+		return errors.New("watch-interval should be at least 1s")
+	}
+
+	if o.MaxItems < 0 {
+		return errors.New("max-items should not be negative")
+	}
+
+	if o.Order != "asc" && o.Order != "desc" {
+		return errors.New("order should be either asc or desc")
+	}
+
+	if _, err := action.OrderBy(o.SortBy, o.Order == "asc"); err != nil {
+		return err
+	}
+
 	return nil
 }
 
 // Run performs the execution of 'config view' sub command
 func (o *Options) Run(_ *cobra.Command, _ []string) error {
-	gvr, _, err := explain.SplitAndParseResourceRequest(o.Resource, o.RESTMapper)
-	if err != nil {
-		return err
-	}
-
-	gvk, err := o.RESTMapper.KindFor(gvr)
+	gvks, err := o.Kinds.Parse(o.Resource)
 	if err != nil {
 		return err
 	}
@@ -194,69 +300,201 @@ func (o *Options) Run(_ *cobra.Command, _ []string) error {
 	// TODO: Version override is not required after tekton results migration to V1 APIs
 	//gvk.Version = "v1beta1"
 
-	v, k := gvk.ToAPIVersionAndKind()
-
 	opts := &action.Options{
-		Filter: o.Filter,
 		ListOptions: metav1.ListOptions{
-			TypeMeta: metav1.TypeMeta{
-				Kind:       k,
-				APIVersion: v,
-			},
 			Limit: int64(o.Limit),
 		},
 		ObjectMeta: metav1.ObjectMeta{
-			Name:            o.Name,
-			Namespace:       o.Namespace,
-			UID:             types.UID(o.UID),
-			Labels:          helper.ParseLabels(o.Labels),
-			Annotations:     helper.ParseAnnotations(o.Annotations),
-			Finalizers:      helper.ParseFinalizers(o.Finalizers),
-			OwnerReferences: helper.ParseOwnerReferences(o.OwnerReferences),
+			Name:      o.Name,
+			Namespace: o.Namespace,
 		},
 	}
 
-	for nextPage := true; nextPage; {
-		ul, err := action.List(o.Client, opts)
-		if err != nil {
+	opts.SetKinds(gvks)
+
+	o.Selector.Apply(opts)
+	if err := o.TimeRanges.Apply(opts); err != nil {
+		return err
+	}
+
+	opts.ListOptions.Continue = o.Continue
+
+	if o.Watch {
+		return o.watch(opts)
+	}
+
+	if o.PrintFlags.OutputFlagSpecified() && !o.wide() {
+		return o.printObjects(opts)
+	}
+
+	interactive := !o.All && !o.SinglePage && o.interactive()
+	if len(gvks) > 1 && !interactive {
+		// items of multiple kinds are grouped into a table per kind across
+		// all the pages, as headers are printed once without prompts
+		list := new(unstructured.UnstructuredList)
+		if err := o.pages(opts, false, func(ul *unstructured.UnstructuredList, _ int32) error {
+			if list.Object == nil {
+				list.Object = ul.Object
+			}
+			list.Items = append(list.Items, ul.Items...)
+			return nil
+		}); err != nil {
 			return err
 		}
+		return o.printList(list, o.NoHeaders)
+	}
+	return o.pages(opts, interactive, func(ul *unstructured.UnstructuredList, n int32) error {
+		// print headers once when pages are streamed without prompts
+		return o.printList(ul, o.NoHeaders || n > 0 && !interactive)
+	})
+}
 
-		if o.PrintFlags.OutputFlagSpecified() {
-			switch len(ul.Items) {
-			case 0:
-				break
-			case 1:
-				return o.PrintObject(ul.Items[0].DeepCopyObject(), o.IOStreams.Out)
-			default:
-				return errors.New("multiple resources found, use --uid flag to select")
-			}
+// printList prints the items as tables
+func (o *Options) printList(ul *unstructured.UnstructuredList, noHeaders bool) error {
+	l := new(printer.List)
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(ul.UnstructuredContent(), l); err != nil {
+		return err
+	}
+	return printer.PrintList(o.IOStreams.Out, l, &printer.Options{
+		AllNamespaces: o.AllNamespaces,
+		Wide:          o.wide(),
+		NoHeaders:     noHeaders,
+	})
+}
+
+// wide checks if the table should be printed with additional columns
+func (o *Options) wide() bool {
+	return *o.PrintFlags.OutputFormat == "wide"
+}
+
+// toPrinter returns the custom-columns printer if requested, or falls back to
+// the generic printers.
+func (o *Options) toPrinter() (printers.ResourcePrinter, error) {
+	o.CustomColumnsFlags.NoHeaders = o.NoHeaders
+	o.CustomColumnsFlags.TemplateArgument = *o.PrintFlags.TemplatePrinterFlags.TemplateArgument
+	p, err := o.CustomColumnsFlags.ToPrinter(*o.PrintFlags.OutputFormat)
+	if !genericclioptions.IsNoCompatiblePrinterError(err) {
+		return p, err
+	}
+	return o.PrintFlags.ToPrinter()
+}
+
+// printObjects prints the items of all fetched pages as a single list with
+// the output printer. An item selected by UID is printed as an object.
+func (o *Options) printObjects(opts *action.Options) error {
+	list := &unstructured.UnstructuredList{
+		Object: map[string]interface{}{
+			"kind":       "List",
+			"apiVersion": "v1",
+		},
+	}
+	if err := o.pages(opts, false, func(ul *unstructured.UnstructuredList, _ int32) error {
+		list.Items = append(list.Items, ul.Items...)
+		return nil
+	}); err != nil {
+		return err
+	}
+	list.SetContinue(opts.ListOptions.Continue)
+
+	if o.Selector.UID != "" && len(list.Items) == 1 {
+		return o.PrintObject(&list.Items[0], o.IOStreams.Out)
+	}
+	return o.PrintObject(list, o.IOStreams.Out)
+}
+
+// pages fetches pages of items and passes them to the print function along
+// with the number of items already printed. Paging stops at the last page or
+// as limited by the paging flags, which leaves the next page token in options.
+func (o *Options) pages(opts *action.Options, interactive bool,
+	print func(ul *unstructured.UnstructuredList, n int32) error) error {
+	n := int32(0)
+	for nextPage := true; nextPage; {
+		// shrink the last page, so the next page token resumes after the last printed item
+		if o.MaxItems > 0 && o.MaxItems-n < o.Limit {
+			opts.ListOptions.Limit = int64(o.MaxItems - n)
 		}
 
-		l := new(printer.List)
-		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(ul.UnstructuredContent(), l); err != nil {
+		ul, err := o.list(opts)
+		if err != nil {
 			return err
 		}
-		if err := printer.PrintList(o.IOStreams.Out, l); err != nil {
+
+		token, _, _ := unstructured.NestedString(ul.Object, "nextPageToken")
+		if err := print(ul, n); err != nil {
 			return err
 		}
-		if o.SinglePage {
-			break
-		}
-		if nextPage = l.NextPageToken != ""; nextPage {
-			opts.ListOptions.Continue = l.NextPageToken
+		n += int32(len(ul.Items))
+
+		nextPage = token != ""
+		opts.ListOptions.Continue = token
+		switch {
+		case !nextPage:
+		case o.MaxItems > 0 && n >= o.MaxItems, o.SinglePage, !o.All && !interactive:
+			// print the token on the error stream to keep the output parsable
+			fmt.Fprintf(o.IOStreams.ErrOut, "Next page token: %s\n", token)
+			nextPage = false
+		case interactive:
 			if err := printers.WriteEscaped(o.IOStreams.Out,
 				"\nNext Page: Press any key to continue, CTRL+ESC to exit!\n\n"); err != nil {
 				return err
 			}
-			if key(Escape) {
-				break
-			}
+			nextPage = !key(Escape)
 		}
 	}
 	return nil
 }
 
+// interactive checks if both input and output are attached to a terminal,
+// so the user can be prompted before fetching the next page.
+func (o *Options) interactive() bool {
+	in, ok := o.IOStreams.In.(*os.File)
+	if !ok || !term.IsTerminal(int(in.Fd())) {
+		return false
+	}
+	out, ok := o.IOStreams.Out.(*os.File)
+	return ok && term.IsTerminal(int(out.Fd()))
+}
+
+// list fetches a page of items ordered by the server. Sort keys which are not
+// supported by the server require all the pages to be fetched and sorted here.
+func (o *Options) list(opts *action.Options) (*unstructured.UnstructuredList, error) {
+	ascending := o.Order == "asc"
+	orderBy, err := action.OrderBy(o.SortBy, ascending)
+	if err != nil {
+		return nil, err
+	}
+	if orderBy != "" {
+		opts.OrderBy = orderBy
+		return action.List(o.Client, opts)
+	}
+
+	// fetch full pages, the items are capped after sorting
+	opts.ListOptions.Limit = int64(o.Limit)
+
+	ul := new(unstructured.UnstructuredList)
+	for nextPage := true; nextPage; {
+		l, err := action.List(o.Client, opts)
+		if err != nil {
+			return nil, err
+		}
+		if ul.Object == nil {
+			ul.Object = l.Object
+		}
+		ul.Items = append(ul.Items, l.Items...)
+		token, _, _ := unstructured.NestedString(l.Object, "nextPageToken")
+		if nextPage = token != ""; nextPage {
+			opts.ListOptions.Continue = token
+		}
+	}
+	ul.Object["nextPageToken"] = ""
+
+	action.Sort(ul.Items, o.SortBy, ascending)
+	if o.MaxItems > 0 && int32(len(ul.Items)) > o.MaxItems {
+		ul.Items = ul.Items[:o.MaxItems]
+	}
+	return ul, nil
+}
+
 func key(k byte) bool {
 	s, err := term.MakeRaw(int(os.Stdin.Fd()))
 	if err != nil {
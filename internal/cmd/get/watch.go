@@ -0,0 +1,150 @@
+package get
+
+import (
+	"context"
+	"github.com/sayan-biswas/kubectl-tekton/internal/printer"
+	"github.com/sayan-biswas/kubectl-tekton/internal/results/action"
+	results "github.com/tektoncd/results/proto/v1alpha2/results_go_proto"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"os"
+	"os/signal"
+	"slices"
+	"time"
+)
+
+// watcher tracks the records already printed by their UID and etag.
+type watcher struct {
+	kind     string
+	seen     map[string]seenRecord
+	lastSeen time.Time
+	headers  bool
+}
+
+// seenRecord is the etag and update time of a printed record
+type seenRecord struct {
+	etag    string
+	updated time.Time
+}
+
+// watch prints the latest page of items, or all the items updated after the
+// given update time, then polls for records updated since the last seen update
+// time and prints only new or changed items until interrupted.
+func (o *Options) watch(opts *action.Options) error {
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+
+	w := &watcher{
+		kind: opts.Kind,
+		seen: map[string]seenRecord{},
+	}
+
+	// the given update time is the starting point, otherwise the latest page
+	poll := opts.Updated.After != nil
+	if !poll {
+		opts.OrderBy = "update_time desc"
+		lrr, err := action.Records(o.Client, opts)
+		if err != nil {
+			return err
+		}
+		// print the latest items at the bottom, as the following updates
+		slices.Reverse(lrr.Records)
+		if err := o.watchPrint(w, lrr.Records); err != nil {
+			return err
+		}
+	}
+
+	opts.OrderBy = "update_time asc"
+	for {
+		if !poll {
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-time.After(o.WatchInterval):
+			}
+		}
+		poll = false
+
+		// filter is inclusive and truncated to seconds, the etag drops the duplicates.
+		// The cursor only moves forward from the given update time.
+		if after := opts.Updated.After; !w.lastSeen.IsZero() && (after == nil || w.lastSeen.After(after.Time)) {
+			opts.Updated.After = &metav1.Time{Time: w.lastSeen}
+		}
+		opts.ListOptions.Continue = ""
+		for nextPage := true; nextPage && ctx.Err() == nil; {
+			lrr, err := action.Records(o.Client, opts)
+			if err != nil {
+				return err
+			}
+			if err := o.watchPrint(w, lrr.Records); err != nil {
+				return err
+			}
+			opts.ListOptions.Continue = lrr.NextPageToken
+			nextPage = lrr.NextPageToken != ""
+		}
+	}
+}
+
+// changed returns the records not seen before or changed since seen, and
+// forgets the records which are not listed by the following polls anymore.
+func (w *watcher) changed(records []*results.Record) []*results.Record {
+	var changed []*results.Record
+	for _, r := range records {
+		t := r.GetUpdateTime().AsTime()
+		if t.After(w.lastSeen) {
+			w.lastSeen = t
+		}
+		if s, ok := w.seen[r.GetUid()]; ok && s.etag == r.GetEtag() {
+			continue
+		}
+		w.seen[r.GetUid()] = seenRecord{etag: r.GetEtag(), updated: t}
+		changed = append(changed, r)
+	}
+
+	// polls list the records updated since the second of the last seen update
+	since := w.lastSeen.Truncate(time.Second)
+	for uid, s := range w.seen {
+		if s.updated.Before(since) {
+			delete(w.seen, uid)
+		}
+	}
+	return changed
+}
+
+// watchPrint prints the records not seen before or changed since seen
+func (o *Options) watchPrint(w *watcher, records []*results.Record) error {
+	changed := w.changed(records)
+	if len(changed) == 0 {
+		return nil
+	}
+
+	items, err := action.Decode(changed)
+	if err != nil {
+		return err
+	}
+
+	if o.PrintFlags.OutputFlagSpecified() && !o.wide() {
+		for i := range items {
+			if err := o.PrintObject(&items[i], o.IOStreams.Out); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	ul := &unstructured.UnstructuredList{
+		Object: map[string]interface{}{"kind": w.kind},
+		Items:  items,
+	}
+	l := new(printer.List)
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(ul.UnstructuredContent(), l); err != nil {
+		return err
+	}
+	defer func() { w.headers = true }()
+	return printer.PrintList(o.IOStreams.Out, l, &printer.Options{
+		AllNamespaces: o.AllNamespaces,
+		Wide:          o.wide(),
+		NoHeaders:     o.NoHeaders || w.headers,
+	})
+}
@@ -0,0 +1,56 @@
+package get
+
+import (
+	results "github.com/tektoncd/results/proto/v1alpha2/results_go_proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestWatcherChanged(t *testing.T) {
+	at := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	record := func(uid, etag string, d time.Duration) *results.Record {
+		return &results.Record{Uid: uid, Etag: etag, UpdateTime: timestamppb.New(at.Add(d))}
+	}
+	w := &watcher{seen: map[string]seenRecord{}}
+
+	polls := []struct {
+		records []*results.Record
+		changed []string
+		seen    int
+	}{
+		{
+			records: []*results.Record{record("a", "1", 0), record("b", "1", 1500*time.Millisecond)},
+			changed: []string{"a", "b"},
+			// a is not listed by the polls since the second of b
+			seen: 1,
+		},
+		{
+			// the inclusive filter lists b again, unchanged
+			records: []*results.Record{record("b", "1", 1500*time.Millisecond), record("c", "1", 1700*time.Millisecond)},
+			changed: []string{"c"},
+			seen:    2,
+		},
+		{
+			records: []*results.Record{record("b", "2", 3*time.Second)},
+			changed: []string{"b"},
+			seen:    1,
+		},
+	}
+	for i, p := range polls {
+		var changed []string
+		for _, r := range w.changed(p.records) {
+			changed = append(changed, r.GetUid())
+		}
+		if !reflect.DeepEqual(changed, p.changed) {
+			t.Errorf("poll %d changed %v, want %v", i, changed, p.changed)
+		}
+		if len(w.seen) != p.seen {
+			t.Errorf("poll %d seen %d records, want %d", i, len(w.seen), p.seen)
+		}
+	}
+	if want := at.Add(3 * time.Second); !w.lastSeen.Equal(want) {
+		t.Errorf("lastSeen = %v, want %v", w.lastSeen, want)
+	}
+}
@@ -0,0 +1,202 @@
+package logs
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/sayan-biswas/kubectl-tekton/internal/helper"
+	"github.com/sayan-biswas/kubectl-tekton/internal/printer"
+	"github.com/sayan-biswas/kubectl-tekton/internal/results/action"
+	pipelinev1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"io"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/cli-runtime/pkg/printers"
+	"knative.dev/pkg/apis"
+	"os"
+	"path"
+	"slices"
+)
+
+// metadataFile is written next to the step logs of each TaskRun
+const metadataFile = "taskrun.json"
+
+// metadata describes a TaskRun in the saved logs
+type metadata struct {
+	Name           string         `json:"name"`
+	Namespace      string         `json:"namespace"`
+	UID            string         `json:"uid"`
+	PipelineRun    string         `json:"pipelineRun,omitempty"`
+	Task           string         `json:"task,omitempty"`
+	Status         string         `json:"status,omitempty"`
+	StartTime      *metav1.Time   `json:"startTime,omitempty"`
+	CompletionTime *metav1.Time   `json:"completionTime,omitempty"`
+	Steps          []stepMetadata `json:"steps,omitempty"`
+}
+
+type stepMetadata struct {
+	Name       string       `json:"name"`
+	Reason     string       `json:"reason,omitempty"`
+	ExitCode   *int32       `json:"exitCode,omitempty"`
+	StartedAt  *metav1.Time `json:"startedAt,omitempty"`
+	FinishedAt *metav1.Time `json:"finishedAt,omitempty"`
+}
+
+// save writes the logs of runs to a directory tree or a tarball, as
+// <pipelinerun>/<task>/<step>.log for PipelineRuns and <taskrun>/<step>.log
+// for TaskRuns, with the metadata of each TaskRun. Runs with the same name
+// are told apart by their UID.
+func (o *Options) save(runs []unstructured.Unstructured, kind string) (err error) {
+	var a helper.Archive
+	if o.Archive != "" {
+		a, err = helper.NewTarArchive(o.Archive)
+		if err != nil {
+			return err
+		}
+	} else {
+		a = helper.NewDirArchive(o.OutputDir)
+	}
+	defer func() {
+		if cerr := a.Close(); err == nil {
+			err = cerr
+		}
+		// a truncated archive is not left behind
+		if err != nil && o.Archive != "" {
+			_ = os.Remove(o.Archive)
+		}
+	}()
+
+	names := map[string]int{}
+	for i := range runs {
+		names[runs[i].GetName()]++
+	}
+
+	count := 0
+	for i := range runs {
+		dir := runs[i].GetName()
+		if names[dir] > 1 {
+			dir = fmt.Sprintf("%s-%s", dir, runs[i].GetUID())
+		}
+		dir = helper.SafeName(dir)
+		n, err := o.saveRun(a, &runs[i], kind, dir)
+		if err != nil {
+			return err
+		}
+		count += n
+	}
+
+	target := o.OutputDir
+	if o.Archive != "" {
+		target = o.Archive
+	}
+	return printers.WriteEscaped(o.IOStreams.Out,
+		fmt.Sprintf("Logs of %d TaskRun(s) written to %s\n", count, target))
+}
+
+// saveRun writes the logs of a run below the directory and returns the number
+// of TaskRuns written.
+func (o *Options) saveRun(a helper.Archive, run *unstructured.Unstructured, kind, root string) (int, error) {
+	trs := []unstructured.Unstructured{*run}
+	if kind == "PipelineRun" {
+		var err error
+		trs, err = action.TaskRuns(o.Client, run)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	count := 0
+	for i := range trs {
+		tr := &trs[i]
+		name := action.LogName(tr)
+		if name == "" || !o.selected(tr) {
+			continue
+		}
+
+		dir := root
+		if kind == "PipelineRun" {
+			dir = path.Join(dir, helper.SafeName(taskName(tr)))
+		}
+
+		// the log is streamed to a file for each step
+		var files []io.Closer
+		sw := printer.NewStepWriter(func(step string) (io.Writer, error) {
+			if len(o.Steps) > 0 && !slices.Contains(o.Steps, step) {
+				return io.Discard, nil
+			}
+			// step names are parsed from the stored log
+			file := helper.SafeName(step)
+			if step == "" {
+				file = "output"
+			}
+			f, err := a.Create(path.Join(dir, file+".log"))
+			if err != nil {
+				return nil, err
+			}
+			files = append(files, f)
+			return f, nil
+		}, o.Redactor)
+
+		err := action.Log(o.Client, &action.Options{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name,
+			},
+		}, sw)
+		if err == nil {
+			err = sw.Flush()
+		}
+		for _, f := range files {
+			if cerr := f.Close(); err == nil {
+				err = cerr
+			}
+		}
+		if err != nil {
+			return count, err
+		}
+
+		m, err := taskRunMetadata(tr)
+		if err != nil {
+			return count, err
+		}
+		if err := a.WriteFile(path.Join(dir, metadataFile), m); err != nil {
+			return count, err
+		}
+		count++
+	}
+	return count, nil
+}
+
+// taskRunMetadata returns the metadata of a TaskRun as indented json
+func taskRunMetadata(u *unstructured.Unstructured) ([]byte, error) {
+	tr := &pipelinev1.TaskRun{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, tr); err != nil {
+		return nil, err
+	}
+
+	m := &metadata{
+		Name:           tr.Name,
+		Namespace:      tr.Namespace,
+		UID:            string(tr.UID),
+		PipelineRun:    tr.Labels["tekton.dev/pipelineRun"],
+		Task:           tr.Labels[action.PipelineTaskLabel],
+		StartTime:      tr.Status.StartTime,
+		CompletionTime: tr.Status.CompletionTime,
+	}
+	if c := tr.Status.GetCondition(apis.ConditionSucceeded); c != nil {
+		m.Status = c.Reason
+	}
+	for _, s := range tr.Status.Steps {
+		sm := stepMetadata{
+			Name: s.Name,
+		}
+		if t := s.Terminated; t != nil {
+			sm.Reason = t.Reason
+			sm.ExitCode = &t.ExitCode
+			sm.StartedAt = &t.StartedAt
+			sm.FinishedAt = &t.FinishedAt
+		}
+		m.Steps = append(m.Steps, sm)
+	}
+
+	return json.MarshalIndent(m, "", "  ")
+}
@@ -0,0 +1,164 @@
+package logs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"golang.org/x/sync/errgroup"
+	"io"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"os"
+	"sync"
+)
+
+// errCanceled fails the writes of runs which are no longer printed
+var errCanceled = errors.New("logs are no longer printed")
+
+// batch prints the logs of multiple runs, each under a header. Logs are fetched
+// concurrently, but printed in the order of the runs: the log of the run at the
+// head of the order is streamed to the output, and the logs of the runs ahead
+// are spooled to temporary files until their turn.
+func (o *Options) batch(runs []unstructured.Unstructured, kind string) error {
+	logs := make([]*runLog, len(runs))
+	for i := range logs {
+		logs[i] = &runLog{done: make(chan error, 1)}
+	}
+	defer func() {
+		for _, l := range logs {
+			l.closeSpool()
+		}
+	}()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	g := errgroup.Group{}
+	g.SetLimit(parallelism)
+	fed := make(chan struct{})
+	go func() {
+		defer close(fed)
+		for i := range runs {
+			if ctx.Err() != nil {
+				return
+			}
+			g.Go(func() error {
+				if ctx.Err() != nil {
+					return nil
+				}
+				logs[i].done <- o.runLogs(&runs[i], kind, logs[i])
+				return nil
+			})
+		}
+	}()
+	// on return, the runs still fetching logs fail on their next write and are
+	// waited for, so no goroutine is left blocked
+	defer func() {
+		cancel()
+		for _, l := range logs {
+			l.cancel()
+		}
+		<-fed
+		_ = g.Wait()
+	}()
+
+	var errs []error
+	for i := range runs {
+		if i > 0 {
+			fmt.Fprintln(o.IOStreams.Out)
+		}
+		fmt.Fprintf(o.IOStreams.Out, "==> %s %s/%s <==\n", kind, runs[i].GetNamespace(), runs[i].GetName())
+		if err := logs[i].head(o.IOStreams.Out); err != nil {
+			return err
+		}
+		err := <-logs[i].done
+		if werr := logs[i].outErr(); werr != nil {
+			return werr
+		}
+		if err != nil {
+			fmt.Fprintf(o.IOStreams.ErrOut, "error: %s/%s: %v\n", runs[i].GetNamespace(), runs[i].GetName(), err)
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// runLog is the log of a run printed in order with the logs of other runs. The
+// log is written to the output once the run is at the head of the order, and
+// spooled to a temporary file until then.
+type runLog struct {
+	sync.Mutex
+	out   io.Writer
+	spool *os.File
+	// err fails the next writes, once the spool or the output failed or the
+	// logs are no longer printed
+	err  error
+	werr error
+	done chan error
+}
+
+func (l *runLog) Write(p []byte) (int, error) {
+	l.Lock()
+	defer l.Unlock()
+	if l.err != nil {
+		return 0, l.err
+	}
+	if l.out != nil {
+		n, err := l.out.Write(p)
+		l.err, l.werr = err, err
+		return n, err
+	}
+	if l.spool == nil {
+		if l.spool, l.err = os.CreateTemp("", "logs-*.log"); l.err != nil {
+			return 0, l.err
+		}
+	}
+	n, err := l.spool.Write(p)
+	l.err = err
+	return n, err
+}
+
+// head writes the spooled log to the output, and the rest of the log is
+// written to the output as received.
+func (l *runLog) head(out io.Writer) error {
+	l.Lock()
+	defer l.Unlock()
+	l.out = out
+	if l.spool == nil {
+		return nil
+	}
+	defer l.removeSpool()
+	if _, err := l.spool.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	_, err := io.Copy(out, l.spool)
+	return err
+}
+
+// outErr returns the error of writing the log to the output
+func (l *runLog) outErr() error {
+	l.Lock()
+	defer l.Unlock()
+	return l.werr
+}
+
+// cancel fails the next writes of the log
+func (l *runLog) cancel() {
+	l.Lock()
+	defer l.Unlock()
+	if l.err == nil {
+		l.err = errCanceled
+	}
+}
+
+func (l *runLog) closeSpool() {
+	l.Lock()
+	defer l.Unlock()
+	l.removeSpool()
+}
+
+// removeSpool removes the temporary file of the log, if any
+func (l *runLog) removeSpool() {
+	if l.spool != nil {
+		l.spool.Close()
+		os.Remove(l.spool.Name())
+		l.spool = nil
+	}
+}
@@ -0,0 +1,63 @@
+package logs
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+)
+
+// failWriter fails all writes
+type failWriter struct{}
+
+func (failWriter) Write([]byte) (int, error) {
+	return 0, io.ErrClosedPipe
+}
+
+func TestRunLog(t *testing.T) {
+	l := &runLog{}
+	defer l.closeSpool()
+
+	// the log is spooled until the run is at the head of the order
+	if _, err := io.WriteString(l, "one\n"); err != nil {
+		t.Fatal(err)
+	}
+	if l.spool == nil {
+		t.Fatal("log written before the head is not spooled")
+	}
+	var out bytes.Buffer
+	if err := l.head(&out); err != nil {
+		t.Fatal(err)
+	}
+	if l.spool != nil {
+		t.Error("spool not removed at the head")
+	}
+	if _, err := io.WriteString(l, "two\n"); err != nil {
+		t.Fatal(err)
+	}
+	if out.String() != "one\ntwo\n" {
+		t.Errorf("output = %q", out.String())
+	}
+
+	l.cancel()
+	if _, err := io.WriteString(l, "three\n"); !errors.Is(err, errCanceled) {
+		t.Errorf("write after cancel = %v", err)
+	}
+	if l.outErr() != nil {
+		t.Errorf("output error after cancel = %v", l.outErr())
+	}
+
+	// the output fails at the head, so the next writes fail
+	l = &runLog{}
+	if err := l.head(failWriter{}); err != nil {
+		t.Fatal(err)
+	}
+	for range 2 {
+		if _, err := io.WriteString(l, "one\n"); !errors.Is(err, io.ErrClosedPipe) {
+			t.Errorf("write to a failed output = %v", err)
+		}
+	}
+	if !errors.Is(l.outErr(), io.ErrClosedPipe) {
+		t.Errorf("output error = %v", l.outErr())
+	}
+}
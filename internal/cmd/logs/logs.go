@@ -3,38 +3,65 @@ package logs
 import (
 	"errors"
 	"fmt"
+	"github.com/sayan-biswas/kubectl-tekton/internal/cmd/flags"
+	"github.com/sayan-biswas/kubectl-tekton/internal/printer"
 	"github.com/sayan-biswas/kubectl-tekton/internal/results/action"
 	"github.com/sayan-biswas/kubectl-tekton/internal/results/client"
 	"github.com/sayan-biswas/kubectl-tekton/internal/results/config"
 	"github.com/spf13/cobra"
-	"github.com/tektoncd/results/pkg/watcher/reconciler/annotation"
+	"io"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 	"k8s.io/cli-runtime/pkg/genericiooptions"
 	"k8s.io/cli-runtime/pkg/printers"
 	"k8s.io/kubectl/pkg/cmd/util"
-	"k8s.io/kubectl/pkg/explain"
 	"k8s.io/kubectl/pkg/scheme"
 	"k8s.io/kubectl/pkg/util/i18n"
 	"k8s.io/kubectl/pkg/util/templates"
+	"regexp"
+	"slices"
 	"strings"
+	"time"
 )
 
+// parallelism is the number of runs fetching logs concurrently
+const parallelism = 5
+
 type Options struct {
 	PrintFlags  *genericclioptions.PrintFlags
 	PrintObject printers.ResourcePrinterFunc
 	ToPrinter   func(*meta.RESTMapping, *bool, bool, bool) (printers.ResourcePrinterFunc, error)
 
-	Namespace string
-	Resource  string
-	Name      string
-	UID       string
-	Limit     int32
+	Namespace     string
+	AllNamespaces bool
+	Resource      string
+	Name          string
+	MaxRuns       int
+	Batch         bool
+	Tasks         []string
+	Steps         []string
+	NoPrefix      bool
+	NoColor       bool
+	OutputDir     string
+	Archive       string
+	Grep          string
+	Before        int
+	After         int
+	Context       int
+	Head          int
+	Tail          int
+	SinceLine     int
+	Timestamps    string
+	NoRedact      bool
+	Redactor      *printer.Redactor
+	grep          *regexp.Regexp
 
 	Client     client.Client
-	RESTMapper meta.RESTMapper
+	Kinds      flags.KindFlags
+	Selector   flags.SelectorFlags
+	TimeRanges flags.TimeRangeFlags
 
 	IOStreams *genericiooptions.IOStreams
 	Factory   util.Factory
@@ -51,6 +78,50 @@ var (
 		kubectl tekton logs tr test 
 		kubectl tekton logs pr test
 
+		# Logs of a PipelineRun include the logs of all its TaskRuns, prefixed with the task and step
+		kubectl tekton logs pr test -n default
+
+		# Get logs for a run without knowing its namespace, -A is --after-context for logs
+		kubectl tekton logs pr test --all-namespaces
+
+		# Get logs of the failed run when the name matches several runs
+		kubectl tekton logs pr test --status=failed
+
+		# Get logs of a failing step of a pipeline task
+		kubectl tekton logs pr test --task build --step compile
+
+		# Get logs without prefixes or colors, e.g. to pipe to other tools
+		kubectl tekton logs tr test --no-prefix --no-color
+
+		# Get logs of the last 5 failed runs of a pipeline, each under a header
+		kubectl tekton logs pr --labels tekton.dev/pipeline=build --status failed --max-runs 5
+
+		# Search logs, with lines of context around the matches. Lines keep their task and step prefix.
+		kubectl tekton logs pr test --grep "error|panic" -C 3
+
+		# Search logs, with lines of context before and after the matches, like grep
+		kubectl tekton logs pr test --grep "error|panic" -B 1 -A 5
+
+		# Print the last lines of the log of each task
+		kubectl tekton logs pr test --tail 20
+
+		# Print the time of each log line, the time since the run started or since the previous line.
+		# Lines without a timestamp of their own take the time of the previous line of their step,
+		# from the start time of the step, and the last line of a step takes its completion time.
+		kubectl tekton logs pr test --timestamps
+		kubectl tekton logs pr test --timestamps=relative-to-run
+		kubectl tekton logs pr test --timestamps=delta
+
+		# Secrets like bearer tokens, AWS keys and private keys are masked, unless disabled
+		kubectl tekton logs pr test --no-redact
+
+		# Save logs of a run as <pipelinerun>/<task>/<step>.log files, with the metadata of each TaskRun
+		kubectl tekton logs pr test --output-dir ./logs
+		kubectl tekton logs pr test --archive test.tar.gz
+
+		# Resolve resource types without the tekton CRDs on the cluster, e.g. for archived history.
+		kubectl tekton logs pr test -n default --offline-kinds
+
 		# Get logs for a particular run using UID
 		kubectl tekton logs tr test --uid f27a6d83-21d3-4256-a8f0-0875b123895f`))
 )
@@ -77,26 +148,51 @@ func Command(s *genericiooptions.IOStreams, f util.Factory) *cobra.Command {
 	}
 
 	o.PrintFlags.AddFlags(c)
-	c.Flags().StringVarP(&o.UID, "uid", "", "", "UID")
+	o.Kinds.AddFlags(c.Flags())
+	c.Flags().BoolVarP(&o.AllNamespaces, "all-namespaces", "", false, "Find resource across all namespaces")
+	o.Selector.AddFlags(c.Flags())
+	c.Flags().StringSliceVarP(&o.Tasks, "task", "", nil, "Print logs of the pipeline tasks only")
+	c.Flags().StringSliceVarP(&o.Steps, "step", "", nil, "Print logs of the steps only")
+	c.Flags().BoolVarP(&o.NoPrefix, "no-prefix", "", false, "Don't prefix log lines with the task and step name")
+	c.Flags().BoolVarP(&o.NoColor, "no-color", "", false, "Don't color the log line prefixes")
+	c.Flags().StringVarP(&o.OutputDir, "output-dir", "", "", "Write logs to files in the directory, one per step")
+	c.Flags().StringVarP(&o.Archive, "archive", "", "", "Write logs to a tar.gz archive, one file per step")
+	c.MarkFlagsMutuallyExclusive("output-dir", "archive")
+	c.Flags().StringVarP(&o.Grep, "grep", "", "", "Print only log lines matching the regular expression")
+	c.Flags().IntVarP(&o.Before, "before-context", "B", 0, "Print lines of context before the matching lines")
+	c.Flags().IntVarP(&o.After, "after-context", "A", 0, "Print lines of context after the matching lines")
+	c.Flags().IntVarP(&o.Context, "context", "C", 0, "Print lines of context around the matching lines")
+	c.Flags().IntVarP(&o.Head, "head", "", 0, "Print only the first lines of each log")
+	c.Flags().IntVarP(&o.Tail, "tail", "", 0, "Print only the last lines of each log")
+	c.Flags().IntVarP(&o.SinceLine, "since-line", "", 0, "Print log lines starting from the line number")
+	c.Flags().StringVarP(&o.Timestamps, "timestamps", "", "",
+		"Print the time of log lines, one of "+strings.Join(printer.Timestamps, ", "))
+	c.Flags().Lookup("timestamps").NoOptDefVal = printer.TimestampsAbsolute
+	c.Flags().BoolVarP(&o.NoRedact, "no-redact", "", false, "Don't mask secrets in the logs")
+	c.Flags().IntVarP(&o.MaxRuns, "max-runs", "", 1, "Maximum number of matching runs to print logs for")
+	o.TimeRanges.AddFlags(c.Flags())
 
 	return c
 }
 
 // PreRun completes the required command-line options
-func (o *Options) PreRun(_ *cobra.Command, args []string) (err error) {
-	printer, err := o.PrintFlags.ToPrinter()
+func (o *Options) PreRun(cmd *cobra.Command, args []string) (err error) {
+	p, err := o.PrintFlags.ToPrinter()
 	if err != nil {
 		return err
 	}
-	o.PrintObject = printer.PrintObj
+	o.PrintObject = p.PrintObj
 
 	o.Namespace, _, err = o.Factory.ToRawKubeConfigLoader().Namespace()
 	if err != nil {
 		return err
 	}
 
-	o.RESTMapper, err = o.Factory.ToRESTMapper()
-	if err != nil {
+	if o.AllNamespaces {
+		o.Namespace = ""
+	}
+
+	if err := o.Kinds.Complete(o.Factory); err != nil {
 		return err
 	}
 
@@ -110,26 +206,58 @@ func (o *Options) PreRun(_ *cobra.Command, args []string) (err error) {
 		return err
 	}
 
+	if !o.NoRedact {
+		e := c.GetExtension()
+		o.Redactor, err = printer.NewRedactor(e.RedactPattern, e.RedactParams)
+		if err != nil {
+			return err
+		}
+	}
+
 	o.Resource = args[0]
 	if len(args) > 1 {
 		o.Name = args[1]
 	}
 
-	if o.Namespace == "" {
+	if o.Namespace == "" && !o.AllNamespaces {
 		return errors.New("namespace must be specified")
 	}
 
+	if o.MaxRuns < 1 {
+		return errors.New("max-runs must be greater than 0")
+	}
+	o.Batch = cmd.Flags().Changed("max-runs")
+
+	if o.Grep != "" {
+		o.grep, err = regexp.Compile(o.Grep)
+		if err != nil {
+			return fmt.Errorf("invalid grep expression: %w", err)
+		}
+	}
+	if o.Before < 0 || o.After < 0 || o.Context < 0 || o.Head < 0 || o.Tail < 0 || o.SinceLine < 0 {
+		return errors.New("line counts must not be negative")
+	}
+	if o.Timestamps != "" && !slices.Contains(printer.Timestamps, o.Timestamps) {
+		return fmt.Errorf("invalid timestamps %q, must be one of %s", o.Timestamps, strings.Join(printer.Timestamps, ", "))
+	}
+	if o.Context > 0 {
+		o.Before = max(o.Before, o.Context)
+		o.After = max(o.After, o.Context)
+	}
+
 	return nil
 }
 
-// Run performs the execution of 'config view' sub command
+// Run performs the execution of 'logs' sub command
 func (o *Options) Run(_ *cobra.Command, _ []string) error {
-	gvr, _, err := explain.SplitAndParseResourceRequest(o.Resource, o.RESTMapper)
+	gvks, err := o.Kinds.Parse(o.Resource)
 	if err != nil {
 		return err
 	}
-
-	gvk, err := o.RESTMapper.KindFor(gvr)
+	if len(gvks) > 1 {
+		return errors.New("logs can be fetched for only one resource type")
+	}
+	gvk := gvks[0]
 
 	// TODO: remove after tekton results migration to V1 APIs
 	//gvk.Version = "v1beta1"
@@ -142,45 +270,178 @@ func (o *Options) Run(_ *cobra.Command, _ []string) error {
 				Kind:       k,
 				APIVersion: v,
 			},
+			Limit: int64(min(o.MaxRuns+1, 100)),
 		},
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      o.Name,
 			Namespace: o.Namespace,
-			UID:       types.UID(o.UID),
 		},
 	}
 
-	ul, err := action.List(o.Client, opts)
-	if err != nil {
+	o.Selector.Apply(opts)
+	if err := o.TimeRanges.Apply(opts); err != nil {
 		return err
 	}
 
-	switch len(ul.Items) {
-	default:
-		return printers.WriteEscaped(o.IOStreams.Out,
-			fmt.Sprintf("Multiple %s found, narrow down with --uid flag.", gvk.Kind))
-	case 0:
+	runs, err := o.runs(opts)
+	if err != nil {
+		return err
+	}
+	switch {
+	case len(runs) == 0:
 		return printers.WriteEscaped(o.IOStreams.Out, fmt.Sprintf("No %s found", gvk.Kind))
-	case 1:
-		break
+	case len(runs) > o.MaxRuns && !o.Batch:
+		return fmt.Errorf("multiple %s found, narrow down with --uid flag or use --max-runs", gvk.Kind)
+	case len(runs) > o.MaxRuns:
+		runs = runs[:o.MaxRuns]
+	}
+
+	if o.OutputDir != "" || o.Archive != "" {
+		return o.save(runs, gvk.Kind)
+	}
+
+	if len(runs) == 1 {
+		return o.runLogs(&runs[0], gvk.Kind, o.IOStreams.Out)
+	}
+	return o.batch(runs, gvk.Kind)
+}
+
+// runs lists the runs matching the selectors, latest updated first. One run
+// more than max-runs is listed to tell if the selectors match more runs.
+func (o *Options) runs(opts *action.Options) ([]unstructured.Unstructured, error) {
+	var runs []unstructured.Unstructured
+	for len(runs) <= o.MaxRuns {
+		ul, err := action.List(o.Client, opts)
+		if err != nil {
+			return nil, err
+		}
+		runs = append(runs, ul.Items...)
+		token, _, _ := unstructured.NestedString(ul.Object, "nextPageToken")
+		if token == "" {
+			break
+		}
+		opts.ListOptions.Continue = token
+	}
+	return runs, nil
+}
+
+// runLogs writes the logs of a PipelineRun or a TaskRun
+func (o *Options) runLogs(run *unstructured.Unstructured, kind string, w io.Writer) error {
+	if kind == "PipelineRun" {
+		return o.pipelineRunLogs(run, w)
 	}
 
-	a, ok := ul.Items[0].GetAnnotations()[annotation.Record]
-	if !ok || a == "" {
-		return printers.WriteEscaped(o.IOStreams.Out, "No logs found")
+	name := action.LogName(run)
+	if name == "" || !o.selected(run) {
+		return printers.WriteEscaped(w, "No logs found\n")
 	}
-	// with v1alpha3 API, end point has changed from records to logs
-	a = strings.Replace(a, "records", "logs", -1)
-	log, err := action.Log(o.Client, &action.Options{
+	lo := o.logOptions()
+	lo.Start = startTime(run)
+	lw := printer.NewLogWriter(w, "", lo)
+	lw.StepTimes = stepTimes(run)
+	if err := action.Log(o.Client, &action.Options{
 		ObjectMeta: metav1.ObjectMeta{
-			Name: a,
+			Name: name,
 		},
-	})
+	}, lw); err != nil {
+		return err
+	}
+	return lw.Flush()
+}
+
+// pipelineRunLogs writes the logs of the TaskRuns of a PipelineRun in the
+// order they were executed, each line prefixed with the task and step name.
+func (o *Options) pipelineRunLogs(pr *unstructured.Unstructured, w io.Writer) error {
+	trs, err := action.TaskRuns(o.Client, pr)
 	if err != nil {
 		return err
 	}
+	if len(trs) == 0 {
+		return printers.WriteEscaped(w, "No logs found\n")
+	}
 
-	_, err = o.IOStreams.Out.Write(log)
+	lo := o.logOptions()
+	lo.Start = startTime(pr)
+	for i := range trs {
+		name := action.LogName(&trs[i])
+		if name == "" || !o.selected(&trs[i]) {
+			continue
+		}
+		lw := printer.NewLogWriter(w, taskName(&trs[i]), lo)
+		lw.StepTimes = stepTimes(&trs[i])
+		if err := action.Log(o.Client, &action.Options{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name,
+			},
+		}, lw); err != nil {
+			return err
+		}
+		if err := lw.Flush(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
 
-	return err
+// logOptions returns the print options of the logs
+func (o *Options) logOptions() *printer.LogOptions {
+	return &printer.LogOptions{
+		Steps:      o.Steps,
+		NoPrefix:   o.NoPrefix,
+		NoColor:    o.NoColor,
+		Grep:       o.grep,
+		Before:     o.Before,
+		After:      o.After,
+		Head:       o.Head,
+		Tail:       o.Tail,
+		SinceLine:  o.SinceLine,
+		Timestamps: o.Timestamps,
+		Redactor:   o.Redactor,
+	}
+}
+
+// startTime returns the start time of a run
+func startTime(u *unstructured.Unstructured) time.Time {
+	s, _, _ := unstructured.NestedString(u.Object, "status", "startTime")
+	t, _ := time.Parse(time.RFC3339, s)
+	return t
+}
+
+// stepTimes returns the start and completion times of the steps of a TaskRun
+func stepTimes(tr *unstructured.Unstructured) map[string]printer.StepTime {
+	times := map[string]printer.StepTime{}
+	steps, _, _ := unstructured.NestedSlice(tr.Object, "status", "steps")
+	for _, step := range steps {
+		m, ok := step.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		name, _, _ := unstructured.NestedString(m, "name")
+		var st printer.StepTime
+		for _, state := range []string{"terminated", "running"} {
+			s, _, _ := unstructured.NestedString(m, state, "startedAt")
+			if t, err := time.Parse(time.RFC3339, s); err == nil {
+				st.Start = t
+				break
+			}
+		}
+		s, _, _ := unstructured.NestedString(m, "terminated", "finishedAt")
+		st.End, _ = time.Parse(time.RFC3339, s)
+		times[name] = st
+	}
+	return times
+}
+
+// selected checks if the TaskRun is selected by the task flag
+func (o *Options) selected(tr *unstructured.Unstructured) bool {
+	return len(o.Tasks) == 0 || slices.Contains(o.Tasks, taskName(tr))
+}
+
+// taskName returns the pipeline task name of a TaskRun, or the TaskRun name
+// when it doesn't belong to a pipeline.
+func taskName(tr *unstructured.Unstructured) string {
+	if task := tr.GetLabels()[action.PipelineTaskLabel]; task != "" {
+		return task
+	}
+	return tr.GetName()
 }
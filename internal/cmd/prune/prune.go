@@ -0,0 +1,253 @@
+package prune
+
+import (
+	"errors"
+	"fmt"
+	"github.com/sayan-biswas/kubectl-tekton/internal/cmd/flags"
+	"github.com/sayan-biswas/kubectl-tekton/internal/helper"
+	"github.com/sayan-biswas/kubectl-tekton/internal/printer"
+	"github.com/sayan-biswas/kubectl-tekton/internal/results/action"
+	"github.com/sayan-biswas/kubectl-tekton/internal/results/client"
+	"github.com/sayan-biswas/kubectl-tekton/internal/results/config"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	"k8s.io/cli-runtime/pkg/printers"
+	"k8s.io/kubectl/pkg/cmd/util"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+type Options struct {
+	Namespace     string
+	AllNamespaces bool
+	Resource      string
+	KeepLast      int
+	OlderThan     string
+	KeepFailed    bool
+
+	Policy   *action.PrunePolicy
+	Client   client.Client
+	Kinds    flags.KindFlags
+	Selector flags.SelectorFlags
+	Remove   flags.RemoveFlags
+
+	IOStreams *genericiooptions.IOStreams
+	Factory   util.Factory
+}
+
+var (
+	short = i18n.T(`Prune resources from tekton results by a retention policy`)
+
+	long = templates.LongDesc(i18n.T(`
+		Prune completed runs from tekton results, keeping the runs selected by the
+		retention policy for each pipeline or task. Runs are grouped by the pipeline
+		or task label, or else the pipeline or task reference. Other runs, like
+		CustomRuns, are grouped by their generateName, or else by their own name.
+		TaskRuns of PipelineRuns are pruned along with their PipelineRun.`))
+
+	example = templates.Examples(i18n.T(`
+		# Keep the last 20 runs of every pipeline, and any run younger than 14 days
+		kubectl tekton prune pr -n default --keep-last 20 --older-than 14d
+
+		# Only print the runs, records, logs and results which would be deleted
+		kubectl tekton prune pr -n default --keep-last 20 --dry-run
+
+		# Keep the runs which didn't succeed for investigation
+		kubectl tekton prune pr,tr -n default --older-than 1w --keep-failed
+
+		# Prune runs across all namespaces without confirmation, e.g. in a cron job
+		kubectl tekton prune all -A --keep-last 50 --yes
+
+		# Back up the records and logs of the pruned runs before deleting them
+		kubectl tekton prune pr -n default --keep-last 20 --backup pruned.ndjson
+
+		# Prune with more concurrent requests and print a report of the deleted and failed resources
+		kubectl tekton prune all -A --keep-last 50 --yes --parallelism 10 -o json`))
+)
+
+func Command(s *genericiooptions.IOStreams, f util.Factory) *cobra.Command {
+	o := &Options{
+		IOStreams: s,
+		Factory:   f,
+	}
+
+	c := &cobra.Command{
+		Use:     "prune [type[,type...]|all]",
+		Short:   short,
+		Long:    long,
+		Example: example,
+		Args:    cobra.ExactArgs(1),
+		PreRunE: o.PreRun,
+		RunE:    o.Run,
+	}
+
+	o.Kinds.AddFlags(c.Flags())
+	c.Flags().BoolVarP(&o.AllNamespaces, "all-namespaces", "A", false, "Prune resources across all namespaces")
+	o.Selector.AddFlags(c.Flags())
+	c.Flags().IntVarP(&o.KeepLast, "keep-last", "", 0, "Keep the latest runs of each pipeline or task")
+	c.Flags().StringVarP(&o.OlderThan, "older-than", "", "",
+		"Prune only runs created before a duration (e.g. 14d, 2w) or RFC3339 time")
+	c.Flags().BoolVarP(&o.KeepFailed, "keep-failed", "", false, "Keep the runs which didn't succeed")
+	o.Remove.AddFlags(c.Flags())
+
+	return c
+}
+
+// PreRun completes the required command-line options
+func (o *Options) PreRun(_ *cobra.Command, args []string) (err error) {
+	o.Namespace, _, err = o.Factory.ToRawKubeConfigLoader().Namespace()
+	if err != nil {
+		return err
+	}
+
+	if o.AllNamespaces {
+		o.Namespace = ""
+	}
+
+	if err := o.Kinds.Complete(o.Factory); err != nil {
+		return err
+	}
+
+	c, err := config.NewConfig(o.Factory)
+	if err != nil {
+		return err
+	}
+
+	o.Client, err = client.NewClient(c.Get())
+	if err != nil {
+		return err
+	}
+
+	o.Resource = args[0]
+
+	if o.Namespace == "" && !o.AllNamespaces {
+		return errors.New("namespace must be specified")
+	}
+
+	if o.KeepLast < 0 {
+		return errors.New("keep-last should not be negative")
+	}
+
+	if o.KeepLast == 0 && o.OlderThan == "" {
+		return errors.New("at least one of keep-last or older-than must be specified")
+	}
+
+	if err := o.Remove.Validate(); err != nil {
+		return err
+	}
+
+	o.Policy = &action.PrunePolicy{
+		KeepLast:   o.KeepLast,
+		KeepFailed: o.KeepFailed,
+	}
+	if o.OlderThan != "" {
+		t, err := helper.ParseTime(o.OlderThan)
+		if err != nil {
+			return err
+		}
+		o.Policy.OlderThan = t.Time
+	}
+
+	return nil
+}
+
+// Run performs the execution of 'prune' sub command
+func (o *Options) Run(_ *cobra.Command, _ []string) error {
+	gvks, err := o.Kinds.Parse(o.Resource)
+	if err != nil {
+		return err
+	}
+
+	opts := &action.Options{
+		OrderBy: "create_time desc",
+		ListOptions: metav1.ListOptions{
+			Limit: 100,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: o.Namespace,
+		},
+	}
+	o.Selector.Apply(opts)
+
+	// records of older watchers are stored with v1beta1 versions of the kinds
+	opts.SetKinds(helper.StoredVersions(gvks))
+
+	var items []unstructured.Unstructured
+	for nextPage := true; nextPage; {
+		ul, err := action.List(o.Client, opts)
+		if err != nil {
+			return err
+		}
+		items = append(items, ul.Items...)
+		token, _, _ := unstructured.NestedString(ul.Object, "nextPageToken")
+		opts.ListOptions.Continue = token
+		nextPage = token != ""
+	}
+
+	// the summary is printed to the error stream when a report is printed
+	out := o.Remove.Out(o.IOStreams)
+
+	groups, pruned := action.Prune(items, o.Policy)
+	if len(groups) == 0 {
+		return printers.WriteEscaped(out, "No completed runs found\n")
+	}
+	if err := printer.PrintPruneGroups(out, groups, nil); err != nil {
+		return err
+	}
+	if len(pruned) == 0 {
+		return printers.WriteEscaped(out, "\nNothing to prune\n")
+	}
+
+	var ds []action.Deletion
+	seen := map[string]bool{}
+	for _, item := range pruned {
+		rds, err := action.Deletions(o.Client, &action.Options{
+			ListOptions: metav1.ListOptions{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       item.GetKind(),
+					APIVersion: item.GetAPIVersion(),
+				},
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        item.GetName(),
+				Namespace:   item.GetNamespace(),
+				UID:         item.GetUID(),
+				Annotations: item.GetAnnotations(),
+			},
+		})
+		if err != nil {
+			return err
+		}
+		for _, d := range rds {
+			if !seen[d.Name] {
+				seen[d.Name] = true
+				ds = append(ds, d)
+			}
+		}
+	}
+	// a result shared by multiple runs is only covered by all of them
+	if ds, err = action.AddResults(o.Client, ds); err != nil {
+		return err
+	}
+
+	if o.Remove.DryRun {
+		fmt.Fprintln(out)
+		if err := printer.PrintDeletions(out, ds, nil); err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "%d run(s) and %d resource(s) would be deleted (dry run).\n", len(pruned), len(ds))
+		return nil
+	}
+
+	ok, err := o.Remove.Confirm(o.IOStreams,
+		fmt.Sprintf("Prune %d run(s) and delete %d resource(s)?", len(pruned), len(ds)))
+	if err != nil || !ok {
+		return err
+	}
+
+	return o.Remove.Remove(o.Client, o.IOStreams, ds, func(r *printer.DeletionReport) string {
+		return fmt.Sprintf("%d run(s) pruned, %d resource(s) deleted, %d failed.", len(pruned), r.Deleted, r.Failed)
+	})
+}
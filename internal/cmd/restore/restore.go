@@ -0,0 +1,129 @@
+package restore
+
+import (
+	"errors"
+	"fmt"
+	"github.com/sayan-biswas/kubectl-tekton/internal/printer"
+	"github.com/sayan-biswas/kubectl-tekton/internal/results/action"
+	"github.com/sayan-biswas/kubectl-tekton/internal/results/client"
+	"github.com/sayan-biswas/kubectl-tekton/internal/results/config"
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	"k8s.io/cli-runtime/pkg/printers"
+	"k8s.io/kubectl/pkg/cmd/util"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+type Options struct {
+	File        string
+	Parallelism int
+	Output      string
+
+	Client client.Client
+
+	IOStreams *genericiooptions.IOStreams
+	Factory   util.Factory
+}
+
+var (
+	short = i18n.T(`Import results, records and logs from a backup into tekton results`)
+
+	long = templates.LongDesc(i18n.T(`
+		Import results, records and logs from a backup written by the --backup flag of
+		delete or prune. The names of the results and records are kept, while the UIDs,
+		etags and timestamps are assigned by the server. Entries which already exist are
+		skipped, so an import can be repeated. Logs are restored with the streaming Logs
+		API, which requires the GRPC client type, so backups with logs are refused with
+		the REST client type.`))
+
+	example = templates.Examples(i18n.T(`
+		# Import a backup written by delete or prune
+		kubectl tekton import backup.tar.gz
+
+		# Import a newline-delimited JSON backup with more concurrent requests
+		kubectl tekton import backup.ndjson --parallelism 10
+
+		# Print a report of the created, existing and failed entries
+		kubectl tekton import backup.ndjson -o json`))
+)
+
+func Command(s *genericiooptions.IOStreams, f util.Factory) *cobra.Command {
+	o := &Options{
+		IOStreams: s,
+		Factory:   f,
+	}
+
+	c := &cobra.Command{
+		Use:     "import FILE",
+		Aliases: []string{"restore"},
+		Short:   short,
+		Long:    long,
+		Example: example,
+		Args:    cobra.ExactArgs(1),
+		PreRunE: o.PreRun,
+		RunE:    o.Run,
+	}
+
+	c.Flags().IntVarP(&o.Parallelism, "parallelism", "", 5, "Number of entries imported concurrently")
+	c.Flags().StringVarP(&o.Output, "output", "o", "",
+		"Print a report of the created, existing and failed entries, one of json or yaml")
+
+	return c
+}
+
+// PreRun completes the required command-line options
+func (o *Options) PreRun(_ *cobra.Command, args []string) (err error) {
+	c, err := config.NewConfig(o.Factory)
+	if err != nil {
+		return err
+	}
+
+	o.Client, err = client.NewClient(c.Get())
+	if err != nil {
+		return err
+	}
+
+	o.File = args[0]
+
+	if o.Parallelism < 1 {
+		return errors.New("parallelism should be at least 1")
+	}
+
+	if o.Output != "" && o.Output != printer.ReportJSON && o.Output != printer.ReportYAML {
+		return fmt.Errorf("output should be one of %s or %s", printer.ReportJSON, printer.ReportYAML)
+	}
+
+	return nil
+}
+
+// Run performs the execution of 'import' sub command
+func (o *Options) Run(_ *cobra.Command, _ []string) error {
+	es, err := action.ReadBackup(o.File)
+	if err != nil {
+		return err
+	}
+	if len(es) == 0 {
+		return printers.WriteEscaped(o.IOStreams.Out, "No entries found\n")
+	}
+	if err := action.CheckRestore(o.Client, es); err != nil {
+		return err
+	}
+
+	r := printer.NewRestoreReport(action.RestoreAll(o.Client, es, &action.RestoreOptions{
+		Parallelism: o.Parallelism,
+		Progress:    printer.Progress(o.IOStreams.ErrOut, "Importing"),
+	}))
+
+	if o.Output == "" {
+		fmt.Fprintf(o.IOStreams.Out, "%d entries imported, %d already existed, %d failed.\n",
+			r.Created, r.Existing, r.Failed)
+	}
+	if err := printer.PrintRestoreReport(o.IOStreams.Out, r, o.Output, nil); err != nil {
+		return err
+	}
+	if r.Failed > 0 {
+		return fmt.Errorf("%d entries failed to be imported", r.Failed)
+	}
+	return nil
+}
@@ -0,0 +1,220 @@
+package helper
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Archive writes files to a directory tree or a compressed tarball.
+type Archive interface {
+	WriteFile(name string, data []byte) error
+	// Create returns a writer for a file, which is written to the archive
+	// when the writer is closed.
+	Create(name string) (io.WriteCloser, error)
+	// Copy writes a file with the size from the reader.
+	Copy(name string, r io.Reader, size int64) error
+	// Sync flushes the files written to the archive and commits them to storage.
+	Sync() error
+	Close() error
+}
+
+// SafeName returns a name which can be used as a single segment of a path in
+// an Archive, with path separators replaced and relative segments escaped.
+func SafeName(name string) string {
+	name = strings.NewReplacer("/", "_", "\\", "_").Replace(name)
+	if name == "" || name == "." || name == ".." {
+		return strings.Repeat("_", max(len(name), 1))
+	}
+	return name
+}
+
+// localPath checks that the name of a file stays below the root of an Archive
+func localPath(name string) (string, error) {
+	p := filepath.FromSlash(name)
+	if !filepath.IsLocal(p) {
+		return "", fmt.Errorf("invalid path %q in archive", name)
+	}
+	return p, nil
+}
+
+// NewDirArchive returns an Archive writing files below the directory.
+func NewDirArchive(dir string) Archive {
+	return &dirArchive{dir: dir}
+}
+
+// NewTarArchive returns an Archive writing files to a tar.gz file, or to an
+// uncompressed tar file when the path ends with .tar.
+func NewTarArchive(path string) (Archive, error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, err
+	}
+	if strings.HasSuffix(path, ".tar") {
+		return &tarArchive{
+			file: f,
+			tar:  tar.NewWriter(f),
+		}, nil
+	}
+	gw := gzip.NewWriter(f)
+	return &tarArchive{
+		file: f,
+		gzip: gw,
+		tar:  tar.NewWriter(gw),
+	}, nil
+}
+
+type dirArchive struct {
+	dir string
+}
+
+func (a *dirArchive) WriteFile(name string, data []byte) error {
+	p, err := localPath(name)
+	if err != nil {
+		return err
+	}
+	path := filepath.Join(a.dir, p)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+func (a *dirArchive) Create(name string) (io.WriteCloser, error) {
+	p, err := localPath(name)
+	if err != nil {
+		return nil, err
+	}
+	path := filepath.Join(a.dir, p)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, err
+	}
+	return os.Create(path)
+}
+
+func (a *dirArchive) Copy(name string, r io.Reader, _ int64) error {
+	f, err := a.Create(name)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(f, r); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// Sync does nothing, as the files are not buffered by the archive
+func (a *dirArchive) Sync() error {
+	return nil
+}
+
+func (a *dirArchive) Close() error {
+	return nil
+}
+
+type tarArchive struct {
+	file *os.File
+	gzip *gzip.Writer
+	tar  *tar.Writer
+}
+
+func (a *tarArchive) WriteFile(name string, data []byte) error {
+	p, err := localPath(name)
+	if err != nil {
+		return err
+	}
+	if err := a.tar.WriteHeader(&tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     filepath.ToSlash(p),
+		Mode:     0o644,
+		Size:     int64(len(data)),
+		ModTime:  time.Now(),
+	}); err != nil {
+		return err
+	}
+	_, err = a.tar.Write(data)
+	return err
+}
+
+// Create spools the file to a temporary file, as the size of the file is
+// written to the archive before the data.
+func (a *tarArchive) Create(name string) (io.WriteCloser, error) {
+	p, err := localPath(name)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.CreateTemp("", "archive-*")
+	if err != nil {
+		return nil, err
+	}
+	return &tarFile{File: f, archive: a, name: filepath.ToSlash(p)}, nil
+}
+
+func (a *tarArchive) Copy(name string, r io.Reader, size int64) error {
+	p, err := localPath(name)
+	if err != nil {
+		return err
+	}
+	if err := a.tar.WriteHeader(&tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     filepath.ToSlash(p),
+		Mode:     0o644,
+		Size:     size,
+		ModTime:  time.Now(),
+	}); err != nil {
+		return err
+	}
+	_, err = io.CopyN(a.tar, r, size)
+	return err
+}
+
+func (a *tarArchive) Sync() error {
+	if err := a.tar.Flush(); err != nil {
+		return err
+	}
+	if a.gzip != nil {
+		if err := a.gzip.Flush(); err != nil {
+			return err
+		}
+	}
+	return a.file.Sync()
+}
+
+func (a *tarArchive) Close() error {
+	if err := a.tar.Close(); err != nil {
+		return err
+	}
+	if a.gzip != nil {
+		if err := a.gzip.Close(); err != nil {
+			return err
+		}
+	}
+	return a.file.Close()
+}
+
+// tarFile is a file spooled to a temporary file until it's closed
+type tarFile struct {
+	*os.File
+	archive *tarArchive
+	name    string
+}
+
+func (f *tarFile) Close() error {
+	defer os.Remove(f.File.Name())
+	defer f.File.Close()
+
+	fi, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	return f.archive.Copy(f.name, f.File, fi.Size())
+}
@@ -0,0 +1,120 @@
+package helper
+
+import (
+	"archive/tar"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestSafeName(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"build", "build"},
+		{"", "_"},
+		{".", "_"},
+		{"..", "__"},
+		{"../../../etc/x", ".._.._.._etc_x"},
+		{`..\..\x`, ".._.._x"},
+		{"step.v1", "step.v1"},
+	}
+	for _, tt := range tests {
+		if got := SafeName(tt.name); got != tt.want {
+			t.Errorf("SafeName(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestDirArchiveWriteFile(t *testing.T) {
+	dir := t.TempDir()
+	a := NewDirArchive(filepath.Join(dir, "out"))
+
+	if err := a.WriteFile("run/task/step.log", []byte("log")); err != nil {
+		t.Fatal(err)
+	}
+	if b, err := os.ReadFile(filepath.Join(dir, "out", "run", "task", "step.log")); err != nil || string(b) != "log" {
+		t.Errorf("ReadFile() = %q, %v", b, err)
+	}
+
+	for _, name := range []string{"../x.log", "run/../../x.log", "/etc/x.log", ""} {
+		if err := a.WriteFile(name, []byte("log")); err == nil {
+			t.Errorf("WriteFile(%q) should fail", name)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(dir, "x.log")); !os.IsNotExist(err) {
+		t.Errorf("file written outside of the archive: %v", err)
+	}
+}
+
+func TestTarArchiveWriteFile(t *testing.T) {
+	a, err := NewTarArchive(filepath.Join(t.TempDir(), "logs.tar.gz"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer a.Close()
+
+	if err := a.WriteFile("run/step.log", []byte("log")); err != nil {
+		t.Fatal(err)
+	}
+	if err := a.WriteFile("run/../../x.log", []byte("log")); err == nil {
+		t.Error("WriteFile() should fail for paths outside of the archive")
+	}
+}
+
+func TestTarArchiveCreate(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "logs.tar")
+	a, err := NewTarArchive(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	files := map[string]string{"run/a.log": "first\n", "run/b.log": "second\n"}
+	var ws []io.WriteCloser
+	for _, name := range []string{"run/a.log", "run/b.log"} {
+		w, err := a.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ws = append(ws, w)
+	}
+	// the files are written concurrently and added when closed
+	for i, name := range []string{"run/a.log", "run/b.log"} {
+		if _, err := io.WriteString(ws[i], files[name]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, w := range ws {
+		if err := w.Close(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := a.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	got := map[string]string{}
+	tr := tar.NewReader(f)
+	for {
+		h, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, _ := io.ReadAll(tr)
+		got[h.Name] = string(b)
+	}
+	if !reflect.DeepEqual(got, files) {
+		t.Errorf("archive = %q, want %q", got, files)
+	}
+}
@@ -0,0 +1,130 @@
+package helper
+
+import (
+	"errors"
+	"fmt"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/kubectl/pkg/explain"
+	"strings"
+)
+
+// All is an alias for all the tekton run types.
+const All = "all"
+
+// allResources are resolved for the All alias, types unknown to the mapper are skipped.
+var allResources = []string{"pipelineruns", "taskruns", "customruns"}
+
+var (
+	pipelineRun = schema.GroupVersionKind{Group: "tekton.dev", Version: "v1", Kind: "PipelineRun"}
+	taskRun     = schema.GroupVersionKind{Group: "tekton.dev", Version: "v1", Kind: "TaskRun"}
+	customRun   = schema.GroupVersionKind{Group: "tekton.dev", Version: "v1beta1", Kind: "CustomRun"}
+)
+
+// kinds is the built-in mapping of tekton resource names to kinds, used when
+// the cluster doesn't serve the tekton resources or can't be reached.
+var kinds = map[string]schema.GroupVersionKind{
+	"pr":           pipelineRun,
+	"prs":          pipelineRun,
+	"pipelinerun":  pipelineRun,
+	"pipelineruns": pipelineRun,
+	"tr":           taskRun,
+	"trs":          taskRun,
+	"taskrun":      taskRun,
+	"taskruns":     taskRun,
+	"customrun":    customRun,
+	"customruns":   customRun,
+}
+
+// ParseResources resolves comma separated resource types to kinds. Types are
+// resolved by the mapper with a fallback to the built-in tekton kinds, or only
+// by the built-in kinds if the mapper is nil.
+func ParseResources(s string, m meta.RESTMapper) ([]schema.GroupVersionKind, error) {
+	var gvks []schema.GroupVersionKind
+	for _, r := range strings.Split(s, ",") {
+		r = strings.TrimSpace(r)
+		if r == "" {
+			continue
+		}
+		if r == All {
+			for _, r := range allResources {
+				if gvk, err := parseResource(r, m); err == nil {
+					gvks = appendKind(gvks, gvk)
+				}
+			}
+			continue
+		}
+		gvk, err := parseResource(r, m)
+		if err != nil {
+			return nil, err
+		}
+		gvks = appendKind(gvks, gvk)
+	}
+	if len(gvks) == 0 {
+		return nil, errors.New("resource type must be specified")
+	}
+	return gvks, nil
+}
+
+func parseResource(r string, m meta.RESTMapper) (schema.GroupVersionKind, error) {
+	if m == nil {
+		return offlineKind(r)
+	}
+	gvr, _, err := explain.SplitAndParseResourceRequest(r, m)
+	if err == nil {
+		var gvk schema.GroupVersionKind
+		if gvk, err = m.KindFor(gvr); err == nil {
+			return gvk, nil
+		}
+	}
+	if gvk, oerr := offlineKind(r); oerr == nil {
+		return gvk, nil
+	}
+	return schema.GroupVersionKind{}, err
+}
+
+// offlineKind resolves a resource name to a built-in tekton kind. Names can be
+// qualified with the group and version, e.g. pipelineruns.v1beta1.tekton.dev
+func offlineKind(r string) (schema.GroupVersionKind, error) {
+	r = strings.ToLower(r)
+	name, qualifier, _ := strings.Cut(r, ".")
+	gvk, ok := kinds[name]
+	switch {
+	case !ok:
+	case qualifier == "", qualifier == gvk.Group:
+		return gvk, nil
+	case strings.HasSuffix(qualifier, "."+gvk.Group):
+		gvk.Version = strings.TrimSuffix(qualifier, "."+gvk.Group)
+		return gvk, nil
+	}
+	return schema.GroupVersionKind{}, fmt.Errorf("the server doesn't have a resource type %q", r)
+}
+
+func appendKind(gvks []schema.GroupVersionKind, gvk schema.GroupVersionKind) []schema.GroupVersionKind {
+	for _, k := range gvks {
+		if k == gvk {
+			return gvks
+		}
+	}
+	return append(gvks, gvk)
+}
+
+// storedVersions are the versions of tekton kinds found in tekton results,
+// records stored by older watchers keep the v1beta1 version.
+var storedVersions = map[schema.GroupKind][]string{
+	pipelineRun.GroupKind(): {"v1", "v1beta1"},
+	taskRun.GroupKind():     {"v1", "v1beta1"},
+}
+
+// StoredVersions returns the kinds along with the other versions of the kinds
+// stored by tekton results.
+func StoredVersions(gvks []schema.GroupVersionKind) []schema.GroupVersionKind {
+	var stored []schema.GroupVersionKind
+	for _, gvk := range gvks {
+		stored = appendKind(stored, gvk)
+		for _, v := range storedVersions[gvk.GroupKind()] {
+			stored = appendKind(stored, gvk.GroupKind().WithVersion(v))
+		}
+	}
+	return stored
+}
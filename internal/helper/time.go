@@ -0,0 +1,50 @@
+package helper
+
+import (
+	"fmt"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const dateLayout = "2006-01-02"
+
+// ParseTime parses a RFC3339 timestamp, a date or a duration. Durations are
+// subtracted from the current time, and besides the standard units also accept
+// days (d) and weeks (w), e.g. 6h, 2d, 1w.
+func ParseTime(s string) (*metav1.Time, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil, nil
+	}
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return &metav1.Time{Time: t}, nil
+	}
+	if t, err := time.ParseInLocation(dateLayout, s, time.Local); err == nil {
+		return &metav1.Time{Time: t}, nil
+	}
+	d, err := ParseDuration(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid time %q, use a duration (e.g. 6h, 2d) or RFC3339 timestamp", s)
+	}
+	return &metav1.Time{Time: time.Now().Add(-d)}, nil
+}
+
+// ParseDuration extends time.ParseDuration with days (d) and weeks (w) units.
+func ParseDuration(s string) (time.Duration, error) {
+	units := map[string]time.Duration{
+		"d": 24 * time.Hour,
+		"w": 7 * 24 * time.Hour,
+	}
+	for u, m := range units {
+		if n, ok := strings.CutSuffix(s, u); ok {
+			v, err := strconv.ParseFloat(n, 64)
+			if err != nil {
+				return 0, err
+			}
+			return time.Duration(v * float64(m)), nil
+		}
+	}
+	return time.ParseDuration(s)
+}
@@ -0,0 +1,61 @@
+package helper
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseDuration(t *testing.T) {
+	tests := []struct {
+		s       string
+		want    time.Duration
+		wantErr bool
+	}{
+		{"90s", 90 * time.Second, false},
+		{"6h", 6 * time.Hour, false},
+		{"2d", 48 * time.Hour, false},
+		{"1.5d", 36 * time.Hour, false},
+		{"1w", 7 * 24 * time.Hour, false},
+		{"xd", 0, true},
+		{"2y", 0, true},
+		{"", 0, true},
+	}
+	for _, tt := range tests {
+		got, err := ParseDuration(tt.s)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParseDuration(%q) = %v, %v, want %v, error %t", tt.s, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestParseTime(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		s       string
+		want    time.Time
+		wantErr bool
+	}{
+		{"2024-05-01T10:00:00Z", time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC), false},
+		{"2024-05-01", time.Date(2024, 5, 1, 0, 0, 0, 0, time.Local), false},
+		{" 2d ", now.Add(-48 * time.Hour), false},
+		{"yesterday", time.Time{}, true},
+	}
+	for _, tt := range tests {
+		got, err := ParseTime(tt.s)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseTime(%q) error = %v, want error %t", tt.s, err, tt.wantErr)
+			continue
+		}
+		if tt.wantErr {
+			continue
+		}
+		// durations are relative to the time of parsing
+		if d := got.Sub(tt.want); d < 0 || d > time.Minute {
+			t.Errorf("ParseTime(%q) = %v, want %v", tt.s, got, tt.want)
+		}
+	}
+
+	if got, err := ParseTime(""); got != nil || err != nil {
+		t.Errorf("ParseTime(\"\") = %v, %v, want nil", got, err)
+	}
+}
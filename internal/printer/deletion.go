@@ -0,0 +1,179 @@
+package printer
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/sayan-biswas/kubectl-tekton/internal/results/action"
+	"golang.org/x/term"
+	"io"
+	"os"
+	"sigs.k8s.io/yaml"
+	"text/tabwriter"
+	"text/template"
+)
+
+const deletionsTemplate = `{{- if not .NoHeaders -}}
+KIND	RUN	NAME
+{{ end -}}
+{{- range $d := .Deletions -}}
+{{ $d.Kind }}	{{ $d.Run }}	{{ $d.Name }}
+{{ end -}}
+`
+
+// PrintDeletions prints the entries removed from tekton results as a table.
+func PrintDeletions(w io.Writer, ds []action.Deletion, o *Options) error {
+	if o == nil {
+		o = new(Options)
+	}
+
+	var data = struct {
+		Deletions []action.Deletion
+		NoHeaders bool
+	}{
+		Deletions: ds,
+		NoHeaders: o.NoHeaders,
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 5, 5, ' ', tabwriter.TabIndent)
+	t := template.Must(template.New("Deletions").Parse(deletionsTemplate))
+
+	if err := t.Execute(tw, data); err != nil {
+		return err
+	}
+	return tw.Flush()
+}
+
+const pruneGroupsTemplate = `{{- if not .NoHeaders -}}
+KIND	NAMESPACE	GROUP	TOTAL	KEPT	PRUNED
+{{ end -}}
+{{- range $g := .Groups -}}
+{{ $g.Kind }}	{{ $g.Namespace }}	{{ or $g.Name "<none>" }}	{{ $g.Total }}	{{ $g.Kept }}	{{ $g.Pruned }}
+{{ end -}}
+`
+
+// PrintPruneGroups prints the counts of the runs kept and pruned in each group as a table.
+func PrintPruneGroups(w io.Writer, groups []action.PruneGroup, o *Options) error {
+	if o == nil {
+		o = new(Options)
+	}
+
+	var data = struct {
+		Groups    []action.PruneGroup
+		NoHeaders bool
+	}{
+		Groups:    groups,
+		NoHeaders: o.NoHeaders,
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 5, 5, ' ', tabwriter.TabIndent)
+	t := template.Must(template.New("PruneGroups").Parse(pruneGroupsTemplate))
+
+	if err := t.Execute(tw, data); err != nil {
+		return err
+	}
+	return tw.Flush()
+}
+
+// Formats of the deletion report
+const (
+	ReportJSON = "json"
+	ReportYAML = "yaml"
+)
+
+// DeletionReport is the outcome of removing entries from tekton results.
+type DeletionReport struct {
+	Deleted int                     `json:"deleted"`
+	Failed  int                     `json:"failed"`
+	Items   []action.DeletionStatus `json:"items"`
+}
+
+// NewDeletionReport counts the entries which were deleted and which failed.
+func NewDeletionReport(statuses []action.DeletionStatus) *DeletionReport {
+	r := &DeletionReport{Items: statuses}
+	for _, s := range statuses {
+		if s.Error != "" {
+			r.Failed++
+		} else {
+			r.Deleted++
+		}
+	}
+	return r
+}
+
+const failuresTemplate = `{{- if not .NoHeaders -}}
+KIND	RUN	NAME	STATUS	ERROR
+{{ end -}}
+{{- range $s := .Items -}}
+{{- if $s.Error -}}
+{{ $s.Kind }}	{{ $s.Run }}	{{ $s.Name }}	{{ $s.Status }}	{{ $s.Error }}
+{{ end -}}
+{{- end -}}
+`
+
+// PrintDeletionReport prints the report as json or yaml, otherwise the failed
+// entries are printed as a table.
+func PrintDeletionReport(w io.Writer, r *DeletionReport, format string, o *Options) error {
+	if format != "" {
+		return printReport(w, r, format)
+	}
+
+	if r.Failed == 0 {
+		return nil
+	}
+
+	if o == nil {
+		o = new(Options)
+	}
+
+	var data = struct {
+		Items     []action.DeletionStatus
+		NoHeaders bool
+	}{
+		Items:     r.Items,
+		NoHeaders: o.NoHeaders,
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 5, 5, ' ', tabwriter.TabIndent)
+	t := template.Must(template.New("Failures").Parse(failuresTemplate))
+
+	if err := t.Execute(tw, data); err != nil {
+		return err
+	}
+	return tw.Flush()
+}
+
+// Progress returns a function printing the progress of the deletions on a
+// single line, or nil when the writer is not a terminal.
+func Progress(w io.Writer, message string) func(done, total int) {
+	if f, ok := w.(*os.File); !ok || !term.IsTerminal(int(f.Fd())) {
+		return nil
+	}
+	return func(done, total int) {
+		fmt.Fprintf(w, "\r%s %d/%d", message, done, total)
+		if done == total {
+			fmt.Fprintln(w)
+		}
+	}
+}
+
+// printReport prints a report as json or yaml
+func printReport(w io.Writer, r any, format string) error {
+	switch format {
+	case ReportJSON:
+		b, err := json.MarshalIndent(r, "", "    ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(w, string(b))
+		return err
+	case ReportYAML:
+		b, err := yaml.Marshal(r)
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(b)
+		return err
+	default:
+		return fmt.Errorf("unsupported report format %q", format)
+	}
+}
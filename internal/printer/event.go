@@ -0,0 +1,86 @@
+package printer
+
+import (
+	"github.com/jonboulle/clockwork"
+	"github.com/tektoncd/cli/pkg/formatted"
+	"io"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"text/template"
+)
+
+const eventsTemplate = `{{- if not .Events -}}
+No events found
+{{ else -}}
+{{- if not .NoHeaders -}}
+LAST SEEN	TYPE	REASON	OBJECT	MESSAGE
+{{ end -}}
+{{- range $e := .Events -}}
+{{ formatAge (lastSeen $e) $.Time }}	{{ $e.Type }}	{{ $e.Reason }}	{{ object $e }}	{{ message $e }}
+{{ end -}}
+{{- end -}}
+`
+
+// PrintEvents prints the events as a table, ordered by the time they were last seen.
+func PrintEvents(w io.Writer, events []corev1.Event, o *Options) error {
+	if o == nil {
+		o = new(Options)
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return lastSeen(events[i]).Before(lastSeen(events[j]))
+	})
+
+	var data = struct {
+		Events    []corev1.Event
+		Time      clockwork.Clock
+		NoHeaders bool
+	}{
+		Events:    events,
+		Time:      clockwork.NewRealClock(),
+		NoHeaders: o.NoHeaders,
+	}
+
+	funcMap := template.FuncMap{
+		"formatAge": formatted.Age,
+		"lastSeen":  lastSeen,
+		"object":    object,
+		"message":   message,
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 5, 5, ' ', tabwriter.TabIndent)
+	t := template.Must(template.New("Events").Funcs(funcMap).Parse(eventsTemplate))
+
+	if err := t.Execute(tw, data); err != nil {
+		return err
+	}
+	return tw.Flush()
+}
+
+// lastSeen returns the time the event was last seen, for events without a
+// last timestamp the event time or the creation time.
+func lastSeen(e corev1.Event) *metav1.Time {
+	switch {
+	case !e.LastTimestamp.IsZero():
+		return &e.LastTimestamp
+	case !e.EventTime.IsZero():
+		return &metav1.Time{Time: e.EventTime.Time}
+	case !e.FirstTimestamp.IsZero():
+		return &e.FirstTimestamp
+	default:
+		return &e.CreationTimestamp
+	}
+}
+
+// object returns the kind and name of the object involved in the event
+func object(e corev1.Event) string {
+	return strings.ToLower(e.InvolvedObject.Kind) + "/" + e.InvolvedObject.Name
+}
+
+// message returns the message of the event on a single line
+func message(e corev1.Event) string {
+	return strings.Join(strings.Fields(e.Message), " ")
+}
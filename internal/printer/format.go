@@ -0,0 +1,75 @@
+package printer
+
+import (
+	"fmt"
+	"knative.dev/pkg/apis"
+)
+
+const (
+	pipelineLabel      = "tekton.dev/pipeline"
+	eventListenerLabel = "triggers.tekton.dev/eventlistener"
+	triggerLabel       = "triggers.tekton.dev/trigger"
+	eventTypeLabel     = "pipelinesascode.tekton.dev/event-type"
+)
+
+// TaskCounts holds the number of child tasks of a PipelineRun by their outcome.
+type TaskCounts struct {
+	Succeeded int
+	Failed    int
+	Skipped   int
+}
+
+// formatValue replaces empty values with a placeholder
+func formatValue(s string) string {
+	if s == "" {
+		return "---"
+	}
+	return s
+}
+
+// pipelineName returns the pipeline name from the label set by the controller,
+// which is also set for resolved and embedded pipelines.
+func pipelineName(i Item) string {
+	if p := i.Labels[pipelineLabel]; p != "" {
+		return p
+	}
+	if r := i.Spec.PipelineRef; r != nil {
+		if r.Name != "" {
+			return r.Name
+		}
+		return r.Resolver
+	}
+	return ""
+}
+
+// trigger returns the source of the event which created the run
+func trigger(i Item) string {
+	switch {
+	case i.Labels[eventListenerLabel] != "" && i.Labels[triggerLabel] != "":
+		return i.Labels[eventListenerLabel] + "/" + i.Labels[triggerLabel]
+	case i.Labels[eventListenerLabel] != "":
+		return i.Labels[eventListenerLabel]
+	case i.Labels[eventTypeLabel] != "":
+		return i.Labels[eventTypeLabel]
+	}
+	return ""
+}
+
+// taskCounts parses the task counts from the condition message of a PipelineRun,
+// which is formatted as "Tasks Completed: 3 (Failed: 1, Cancelled 0), Skipped: 1".
+func taskCounts(i Item) TaskCounts {
+	tc := TaskCounts{
+		Skipped: len(i.Status.SkippedTasks),
+	}
+	c := i.Status.GetCondition(apis.ConditionSucceeded)
+	if c == nil {
+		return tc
+	}
+	var completed, failed, cancelled int
+	if _, err := fmt.Sscanf(c.Message, "Tasks Completed: %d (Failed: %d, Cancelled %d)",
+		&completed, &failed, &cancelled); err == nil {
+		tc.Succeeded = completed - failed - cancelled
+		tc.Failed = failed
+	}
+	return tc
+}
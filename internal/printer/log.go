@@ -0,0 +1,465 @@
+package printer
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/fatih/color"
+	"hash/fnv"
+	"io"
+	"regexp"
+	"slices"
+	"time"
+)
+
+// Timestamp modes of the log lines
+const (
+	TimestampsAbsolute = "absolute"
+	TimestampsRelative = "relative-to-run"
+	TimestampsDelta    = "delta"
+)
+
+// Timestamps are the supported timestamp modes
+var Timestamps = []string{TimestampsAbsolute, TimestampsRelative, TimestampsDelta}
+
+// timestampLayout is used for absolute timestamps, with a fixed width
+const timestampLayout = "2006-01-02T15:04:05.000Z07:00"
+
+// stepPrefix matches the step prefix of the lines in stored TaskRun logs
+var stepPrefix = regexp.MustCompile(`^\[([^\]]+)\] ?`)
+
+// stepColors are used to tell apart the lines of different steps
+var stepColors = []color.Attribute{
+	color.FgBlue,
+	color.FgGreen,
+	color.FgMagenta,
+	color.FgCyan,
+	color.FgYellow,
+	color.FgHiBlue,
+	color.FgHiGreen,
+	color.FgHiMagenta,
+	color.FgHiCyan,
+	color.FgHiYellow,
+}
+
+// LogOptions controls how stored logs are printed
+type LogOptions struct {
+	// Steps selects the lines of the steps, all steps are printed when empty.
+	Steps    []string
+	NoPrefix bool
+	NoColor  bool
+
+	// Grep selects the lines matching the expression, with Before and After
+	// lines of context around the matches.
+	Grep   *regexp.Regexp
+	Before int
+	After  int
+	// Head and Tail limit the printed lines to the first or last lines.
+	Head int
+	Tail int
+	// SinceLine skips the lines before the line number of the log.
+	SinceLine int
+
+	// Timestamps annotates the lines with their time in one of the timestamp
+	// modes, relative times are measured from the Start of the run.
+	Timestamps string
+	Start      time.Time
+
+	// Redactor masks secrets of the lines, when set.
+	Redactor *Redactor
+}
+
+// logLine is a formatted line, its line number in the log and its time, which
+// is formatted when the line is printed. Lines starting a new group of context
+// lines are preceded by a separator.
+type logLine struct {
+	n    int
+	t    time.Time
+	text string
+	sep  bool
+}
+
+// heldLine is a read line of a step, before it's filtered
+type heldLine struct {
+	step string
+	text []byte
+}
+
+// StepTime is the start and completion time of a step
+type StepTime struct {
+	Start time.Time
+	End   time.Time
+}
+
+// LogWriter writes stored TaskRun logs line by line, prefixing each line
+// with the task and the step which produced it.
+type LogWriter struct {
+	Out     io.Writer
+	Task    string
+	Options *LogOptions
+	// StepTimes are the start and completion times of the steps, which bound
+	// the time of the lines without a timestamp of their own.
+	StepTimes map[string]StepTime
+
+	step string
+	buf  []byte
+	// held is the last read line, held back until the next line tells if it's
+	// the last line of its step, and readStep is its step
+	held     *heldLine
+	readStep string
+	// stepTime is the time of the previous line of the step
+	stepTime time.Time
+
+	// line numbers of the last read and printed lines, and the printed line count
+	read    int
+	printed int
+	count   int
+	// context lines before the next match and lines left after the last match
+	before []logLine
+	after  int
+	tail   []logLine
+	// time of the previous printed line, for the delta timestamps
+	last time.Time
+	// redactor masks secrets of the lines, when set
+	redactor *lineRedactor
+}
+
+// NewLogWriter returns a LogWriter for the logs of a task, the task can be
+// empty for the logs of a standalone TaskRun.
+func NewLogWriter(w io.Writer, task string, o *LogOptions) *LogWriter {
+	if o == nil {
+		o = &LogOptions{}
+	}
+	lw := &LogWriter{
+		Out:     w,
+		Task:    task,
+		Options: o,
+	}
+	if o.Redactor != nil {
+		lw.redactor = &lineRedactor{Redactor: o.Redactor}
+	}
+	return lw
+}
+
+// Write buffers partial lines until they are completed by a later write.
+func (w *LogWriter) Write(p []byte) (int, error) {
+	w.buf = append(w.buf, p...)
+	for {
+		i := bytes.IndexByte(w.buf, '\n')
+		if i < 0 {
+			break
+		}
+		if err := w.readLine(w.buf[:i]); err != nil {
+			return 0, err
+		}
+		w.buf = w.buf[i+1:]
+	}
+	return len(p), nil
+}
+
+// Flush writes the last line when the log doesn't end with a new line, and
+// the lines held back for the tail.
+func (w *LogWriter) Flush() error {
+	if len(w.buf) > 0 {
+		if err := w.readLine(w.buf); err != nil {
+			return err
+		}
+		w.buf = nil
+	}
+	if w.held != nil {
+		if err := w.line(w.held, true); err != nil {
+			return err
+		}
+		w.held = nil
+	}
+	for i, l := range w.tail {
+		// the tail starts with a line, not a separator
+		l.sep = l.sep && i > 0
+		if err := w.output(l); err != nil {
+			return err
+		}
+	}
+	w.tail = nil
+	return nil
+}
+
+// readLine reads a line, lines without a step prefix belong to the last step.
+// The line is held back until the next line is read, as the last line of a
+// step takes the completion time of the step.
+func (w *LogWriter) readLine(l []byte) error {
+	step := w.readStep
+	if m := stepPrefix.FindSubmatchIndex(l); m != nil {
+		step = string(l[m[2]:m[3]])
+		l = l[m[1]:]
+	}
+	w.readStep = step
+
+	var err error
+	if w.held != nil {
+		err = w.line(w.held, w.held.step != step)
+	}
+	w.held = &heldLine{step: step, text: bytes.Clone(l)}
+	return err
+}
+
+// line filters a single line, end tells if it's the last line of its step.
+func (w *LogWriter) line(h *heldLine, end bool) error {
+	w.read++
+	if w.read == 1 || h.step != w.step {
+		w.step = h.step
+		w.stepTime = w.StepTimes[w.step].Start
+	}
+	t, l := w.timestamp(h.text, end)
+	if w.redactor != nil {
+		l = w.redactor.line(l)
+	}
+	if len(w.Options.Steps) > 0 && !slices.Contains(w.Options.Steps, w.step) {
+		return nil
+	}
+	if w.read < w.Options.SinceLine {
+		return nil
+	}
+
+	line := logLine{n: w.read, t: t, text: w.format(l)}
+	if w.Options.Grep == nil {
+		return w.print(line)
+	}
+
+	switch {
+	case w.Options.Grep.Match(l):
+		for _, b := range w.before {
+			if err := w.print(b); err != nil {
+				return err
+			}
+		}
+		w.before = nil
+		w.after = w.Options.After
+		return w.print(line)
+	case w.after > 0:
+		w.after--
+		return w.print(line)
+	case w.Options.Before > 0:
+		w.before = append(w.before, line)
+		if len(w.before) > w.Options.Before {
+			w.before = w.before[1:]
+		}
+	}
+	return nil
+}
+
+// print writes a line unless it's beyond the head, the lines of the tail are
+// held back until the log is flushed. Non-adjacent groups of context lines are
+// separated like grep does.
+func (w *LogWriter) print(l logLine) error {
+	if w.Options.Head > 0 && w.count >= w.Options.Head {
+		return nil
+	}
+	w.count++
+
+	context := w.Options.Grep != nil && (w.Options.Before > 0 || w.Options.After > 0)
+	l.sep = context && w.printed > 0 && l.n > w.printed+1
+	w.printed = l.n
+	return w.write(l)
+}
+
+// write writes a line, or keeps it when it might be in the tail. Only the log
+// lines count against the tail, separators are written along with their line.
+func (w *LogWriter) write(l logLine) error {
+	if w.Options.Tail > 0 {
+		w.tail = append(w.tail, l)
+		if len(w.tail) > w.Options.Tail {
+			w.tail = w.tail[1:]
+		}
+		return nil
+	}
+	return w.output(l)
+}
+
+// output writes a line with its timestamp, and the separator before it.
+func (w *LogWriter) output(l logLine) error {
+	if l.sep {
+		if _, err := io.WriteString(w.Out, "--\n"); err != nil {
+			return err
+		}
+	}
+	if ts := w.formatTime(l.t); ts != "" {
+		if _, err := io.WriteString(w.Out, ts+" "); err != nil {
+			return err
+		}
+	}
+	_, err := io.WriteString(w.Out, l.text)
+	return err
+}
+
+// format returns the line with the task and step prefix
+func (w *LogWriter) format(l []byte) string {
+	if w.Options.NoPrefix {
+		return fmt.Sprintf("%s\n", l)
+	}
+	return fmt.Sprintf("%s %s\n", w.prefix(), l)
+}
+
+// timestamp returns the time of a line and the line without its timestamp.
+// Lines without a leading RFC3339 timestamp take the time of the previous line
+// of their step, starting at the start time of the step, and the last line of
+// a step takes the completion time of the step. Lines of unknown time have a
+// zero time.
+func (w *LogWriter) timestamp(l []byte, end bool) (time.Time, []byte) {
+	if w.Options.Timestamps == "" {
+		return time.Time{}, l
+	}
+
+	field, rest, _ := bytes.Cut(l, []byte(" "))
+	if t, err := time.Parse(time.RFC3339Nano, string(field)); err == nil {
+		w.stepTime = t
+		return t, rest
+	}
+
+	st := w.StepTimes[w.step]
+	t := w.stepTime
+	switch {
+	case end && !st.End.IsZero(), !st.End.IsZero() && t.After(st.End):
+		t = st.End
+	case t.Before(st.Start):
+		t = st.Start
+	}
+	w.stepTime = t
+	return t, l
+}
+
+// formatTime formats the time of a printed line in the timestamp mode, times
+// of delta timestamps are measured from the previous printed line. Lines of
+// unknown time are marked with a dash.
+func (w *LogWriter) formatTime(t time.Time) string {
+	switch w.Options.Timestamps {
+	case "":
+		return ""
+	case TimestampsRelative:
+		if t.IsZero() || w.Options.Start.IsZero() {
+			return fmt.Sprintf("%12s", "-")
+		}
+		return formatOffset(t.Sub(w.Options.Start))
+	case TimestampsDelta:
+		if t.IsZero() {
+			return fmt.Sprintf("%12s", "-")
+		}
+		last := w.last
+		if last.IsZero() {
+			last = t
+		}
+		w.last = t
+		return formatOffset(t.Sub(last))
+	default:
+		if t.IsZero() {
+			return fmt.Sprintf("%-*s", len(timestampLayout), "-")
+		}
+		return t.Local().Format(timestampLayout)
+	}
+}
+
+// formatOffset formats a signed duration with millisecond precision, right aligned
+func formatOffset(d time.Duration) string {
+	s := d.Round(time.Millisecond).String()
+	if d >= 0 {
+		s = "+" + s
+	}
+	return fmt.Sprintf("%12s", s)
+}
+
+// prefix returns the task and step prefix, colored by the step
+func (w *LogWriter) prefix() string {
+	var p string
+	switch {
+	case w.Task == "":
+		p = w.step
+	case w.step == "":
+		p = w.Task
+	default:
+		p = fmt.Sprintf("%s : %s", w.Task, w.step)
+	}
+	p = "[" + p + "]"
+
+	if w.Options.NoColor {
+		return p
+	}
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(p))
+	return color.New(stepColors[h.Sum32()%uint32(len(stepColors))]).Sprint(p)
+}
+
+// StepWriter splits stored TaskRun logs by the step prefixes of the lines
+// while they are written, and writes the lines of each step without the
+// prefix to the writer opened for the step. Lines without a step prefix
+// belong to the last step.
+type StepWriter struct {
+	// Open returns the writer for the lines of a step, and is called once for
+	// each step in the order the steps appear.
+	Open func(step string) (io.Writer, error)
+
+	step     string
+	buf      []byte
+	writers  map[string]io.Writer
+	redactor *lineRedactor
+}
+
+// NewStepWriter returns a StepWriter masking secrets with the redactor, when set.
+func NewStepWriter(open func(step string) (io.Writer, error), r *Redactor) *StepWriter {
+	w := &StepWriter{
+		Open:    open,
+		writers: map[string]io.Writer{},
+	}
+	if r != nil {
+		w.redactor = &lineRedactor{Redactor: r}
+	}
+	return w
+}
+
+// Write buffers partial lines until they are completed by a later write.
+func (w *StepWriter) Write(p []byte) (int, error) {
+	w.buf = append(w.buf, p...)
+	for {
+		i := bytes.IndexByte(w.buf, '\n')
+		if i < 0 {
+			break
+		}
+		if err := w.line(w.buf[:i], true); err != nil {
+			return 0, err
+		}
+		w.buf = w.buf[i+1:]
+	}
+	return len(p), nil
+}
+
+// Flush writes the last line when the log doesn't end with a new line.
+func (w *StepWriter) Flush() error {
+	if len(w.buf) == 0 {
+		return nil
+	}
+	err := w.line(w.buf, false)
+	w.buf = nil
+	return err
+}
+
+func (w *StepWriter) line(l []byte, newline bool) error {
+	if m := stepPrefix.FindSubmatchIndex(l); m != nil {
+		w.step = string(l[m[2]:m[3]])
+		l = l[m[1]:]
+	}
+	if w.redactor != nil {
+		l = w.redactor.line(l)
+	}
+
+	out, ok := w.writers[w.step]
+	if !ok {
+		var err error
+		if out, err = w.Open(w.step); err != nil {
+			return err
+		}
+		w.writers[w.step] = out
+	}
+
+	if newline {
+		l = append(l[:len(l):len(l)], '\n')
+	}
+	_, err := out.Write(l)
+	return err
+}
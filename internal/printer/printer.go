@@ -1,6 +1,7 @@
 package printer
 
 import (
+	"fmt"
 	"github.com/jonboulle/clockwork"
 	"github.com/tektoncd/cli/pkg/formatted"
 	"io"
@@ -11,6 +12,13 @@ import (
 	cliopts "k8s.io/cli-runtime/pkg/genericclioptions"
 )
 
+// Options controls how a list is rendered as a table.
+type Options struct {
+	AllNamespaces bool
+	NoHeaders     bool
+	Wide          bool
+}
+
 func PrintObject(w io.Writer, o runtime.Object, f *cliopts.PrintFlags) error {
 	printer, err := f.ToPrinter()
 	if err != nil {
@@ -19,8 +27,42 @@ func PrintObject(w io.Writer, o runtime.Object, f *cliopts.PrintFlags) error {
 	return printer.PrintObj(o, w)
 }
 
-func PrintList(w io.Writer, l *List) error {
+// PrintList prints the list as a table. Items of a list with multiple kinds
+// are grouped by kind and printed as separate tables.
+func PrintList(w io.Writer, l *List, o *Options) error {
+	if o == nil {
+		o = new(Options)
+	}
+
+	if l.Kind != "" || len(l.Items) == 0 {
+		return printTable(w, l, o)
+	}
+
+	var kinds []string
+	groups := map[string][]Item{}
+	for _, item := range l.Items {
+		if _, ok := groups[item.Kind]; !ok {
+			kinds = append(kinds, item.Kind)
+		}
+		groups[item.Kind] = append(groups[item.Kind], item)
+	}
+	for i, kind := range kinds {
+		if i > 0 {
+			if _, err := fmt.Fprintln(w); err != nil {
+				return err
+			}
+		}
+		if err := printTable(w, &List{
+			TypeMeta: runtime.TypeMeta{Kind: kind},
+			Items:    groups[kind],
+		}, o); err != nil {
+			return err
+		}
+	}
+	return nil
+}
 
+func printTable(w io.Writer, l *List, o *Options) error {
 	var data = struct {
 		List          *List
 		Time          clockwork.Clock
@@ -29,18 +71,22 @@ func PrintList(w io.Writer, l *List) error {
 	}{
 		List:          l,
 		Time:          clockwork.NewRealClock(),
-		AllNamespaces: false,
-		NoHeaders:     false,
+		AllNamespaces: o.AllNamespaces,
+		NoHeaders:     o.NoHeaders,
 	}
 
 	funcMap := template.FuncMap{
 		"formatAge":       formatted.Age,
 		"formatDuration":  formatted.Duration,
 		"formatCondition": formatted.Condition,
+		"formatValue":     formatValue,
+		"pipelineName":    pipelineName,
+		"trigger":         trigger,
+		"taskCounts":      taskCounts,
 	}
 
 	tw := tabwriter.NewWriter(w, 0, 5, 5, ' ', tabwriter.TabIndent)
-	t := template.Must(template.New("List PipelineRuns").Funcs(funcMap).Parse(listTemplate))
+	t := template.Must(template.New("List " + l.Kind).Funcs(funcMap).Parse(listTemplate(columns(l.Kind, o))))
 
 	err := t.Execute(tw, data)
 	if err != nil {
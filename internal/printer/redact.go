@@ -0,0 +1,150 @@
+package printer
+
+import (
+	"fmt"
+	"io"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/cli-runtime/pkg/printers"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// Redacted replaces the redacted values
+const Redacted = "[REDACTED]"
+
+var (
+	// redactPatterns match secrets commonly found in logs and specs
+	redactPatterns = []*regexp.Regexp{
+		regexp.MustCompile(`(?i)\bbearer\s+[a-z0-9\-._~+/]+=*`),
+		regexp.MustCompile(`\b(?:AKIA|ASIA)[0-9A-Z]{16}\b`),
+		regexp.MustCompile(`(?i)\baws_secret_access_key\b\s*[=:]\s*\S+`),
+		regexp.MustCompile(`-----BEGIN [A-Z ]*PRIVATE KEY-----[\s\S]*?-----END [A-Z ]*PRIVATE KEY-----`),
+	}
+
+	// redactParams match names of params and env variables holding secrets
+	redactParams = []string{"*token*", "*password*", "*passwd*", "*secret*", "*apikey*", "*api-key*", "*api_key*"}
+
+	// private key blocks span multiple lines of logs
+	keyBegin = regexp.MustCompile(`-----BEGIN [A-Z ]*PRIVATE KEY-----`)
+	keyEnd   = regexp.MustCompile(`-----END [A-Z ]*PRIVATE KEY-----`)
+)
+
+// Redactor masks secrets in logs and objects.
+type Redactor struct {
+	patterns []*regexp.Regexp
+	params   []string
+}
+
+// NewRedactor returns a Redactor masking the built-in patterns and the value
+// of params with built-in names, in addition to values matching the pattern
+// and params with names matching the comma separated glob patterns.
+func NewRedactor(pattern, params string) (*Redactor, error) {
+	r := &Redactor{
+		patterns: redactPatterns,
+		params:   redactParams,
+	}
+	if pattern != "" {
+		p, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid redact pattern: %w", err)
+		}
+		r.patterns = append(r.patterns, p)
+	}
+	for _, p := range strings.Split(params, ",") {
+		p = strings.ToLower(strings.TrimSpace(p))
+		if p == "" {
+			continue
+		}
+		if _, err := path.Match(p, ""); err != nil {
+			return nil, fmt.Errorf("invalid redact params pattern %q: %w", p, err)
+		}
+		r.params = append(r.params, p)
+	}
+	return r, nil
+}
+
+// String masks the values matching the patterns.
+func (r *Redactor) String(s string) string {
+	for _, p := range r.patterns {
+		s = p.ReplaceAllString(s, Redacted)
+	}
+	return s
+}
+
+// lineRedactor masks secrets of the lines of a log, the lines of private key
+// blocks are masked as a whole as the blocks span multiple lines.
+type lineRedactor struct {
+	*Redactor
+	// the lines are in a private key block
+	key bool
+}
+
+func (r *lineRedactor) line(l []byte) []byte {
+	if r.key {
+		if keyEnd.Match(l) {
+			r.key = false
+		}
+		return []byte(Redacted)
+	}
+	if i := keyBegin.FindIndex(l); i != nil && !keyEnd.Match(l[i[1]:]) {
+		r.key = true
+		return append(l[:i[0]:i[0]], Redacted...)
+	}
+	return []byte(r.String(string(l)))
+}
+
+// Object masks the values of params and env variables with secret names, and
+// the string values matching the patterns.
+func (r *Redactor) Object(u *unstructured.Unstructured) {
+	u.Object = r.value(u.Object).(map[string]interface{})
+}
+
+func (r *Redactor) value(v interface{}) interface{} {
+	switch v := v.(type) {
+	case string:
+		return r.String(v)
+	case []interface{}:
+		for i := range v {
+			v[i] = r.value(v[i])
+		}
+	case map[string]interface{}:
+		name, ok := v["name"].(string)
+		_, hasValue := v["value"]
+		if ok && hasValue && r.param(name) {
+			v["value"] = Redacted
+		}
+		for k := range v {
+			v[k] = r.value(v[k])
+		}
+	}
+	return v
+}
+
+// param checks if the param name matches the secret names
+func (r *Redactor) param(name string) bool {
+	name = strings.ToLower(name)
+	for _, p := range r.params {
+		if ok, _ := path.Match(p, name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// Printer returns a printer masking secrets of the unstructured objects and
+// lists before printing them with the printer.
+func (r *Redactor) Printer(p printers.ResourcePrinterFunc) printers.ResourcePrinterFunc {
+	return func(o runtime.Object, w io.Writer) error {
+		switch o := o.(type) {
+		case *unstructured.Unstructured:
+			r.Object(o)
+		case *unstructured.UnstructuredList:
+			for i := range o.Items {
+				r.Object(&o.Items[i])
+			}
+		}
+		return p(o, w)
+	}
+}
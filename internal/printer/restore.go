@@ -0,0 +1,66 @@
+package printer
+
+import (
+	"github.com/sayan-biswas/kubectl-tekton/internal/results/action"
+	"io"
+	"net/http"
+	"text/tabwriter"
+	"text/template"
+)
+
+// RestoreReport is the outcome of restoring entries of a backup.
+type RestoreReport struct {
+	Created  int                    `json:"created"`
+	Existing int                    `json:"existing"`
+	Failed   int                    `json:"failed"`
+	Items    []action.RestoreStatus `json:"items"`
+}
+
+// NewRestoreReport counts the entries which were created, which already
+// existed and which failed.
+func NewRestoreReport(statuses []action.RestoreStatus) *RestoreReport {
+	r := &RestoreReport{Items: statuses}
+	for _, s := range statuses {
+		switch {
+		case s.Error != "":
+			r.Failed++
+		case s.Status == http.StatusCreated:
+			r.Created++
+		default:
+			r.Existing++
+		}
+	}
+	return r
+}
+
+// PrintRestoreReport prints the report as json or yaml, otherwise the failed
+// entries are printed as a table.
+func PrintRestoreReport(w io.Writer, r *RestoreReport, format string, o *Options) error {
+	if format != "" {
+		return printReport(w, r, format)
+	}
+
+	if r.Failed == 0 {
+		return nil
+	}
+
+	if o == nil {
+		o = new(Options)
+	}
+
+	var data = struct {
+		Items     []action.RestoreStatus
+		NoHeaders bool
+	}{
+		Items:     r.Items,
+		NoHeaders: o.NoHeaders,
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 5, 5, ' ', tabwriter.TabIndent)
+	t := template.Must(template.New("Failures").Parse(failuresTemplate))
+
+	if err := t.Execute(tw, data); err != nil {
+		return err
+	}
+	return tw.Flush()
+}
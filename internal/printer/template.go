@@ -1,18 +1,96 @@
 package printer
 
-const listTemplate = `{{- $length := len .List.Items -}}{{- if eq $length 0 -}}
-No {{ .List.Kind }} found
+import (
+	"strings"
+)
+
+// Column defines a table column with a template to render the value of an item.
+// Wide columns are only printed with the wide output.
+type Column struct {
+	Name     string
+	Template string
+	Wide     bool
+}
+
+var (
+	nameColumn      = Column{Name: "NAME", Template: "{{ $item.Name }}"}
+	namespaceColumn = Column{Name: "NAMESPACE", Template: "{{ $item.Namespace }}"}
+	uidColumn       = Column{Name: "UID", Template: "{{ $item.UID }}"}
+	startedColumn   = Column{Name: "STARTED", Template: "{{ formatAge $item.Status.StartTime $.Time }}"}
+	durationColumn  = Column{Name: "DURATION", Template: "{{ formatDuration $item.Status.StartTime $item.Status.CompletionTime }}"}
+	statusColumn    = Column{Name: "STATUS", Template: "{{ formatCondition $item.Status.Conditions }}"}
+)
+
+// tables defines the columns for each kind, kinds not listed here use the
+// columns defined for the empty kind.
+var tables = map[string][]Column{
+	"": {
+		nameColumn,
+		uidColumn,
+		startedColumn,
+		durationColumn,
+		statusColumn,
+	},
+	"PipelineRun": {
+		nameColumn,
+		uidColumn,
+		{Name: "PIPELINE", Template: "{{ formatValue (pipelineName $item) }}"},
+		startedColumn,
+		durationColumn,
+		statusColumn,
+		{Name: "TRIGGER", Template: "{{ formatValue (trigger $item) }}", Wide: true},
+		{Name: "SUCCEEDED", Template: "{{ (taskCounts $item).Succeeded }}", Wide: true},
+		{Name: "FAILED", Template: "{{ (taskCounts $item).Failed }}", Wide: true},
+		{Name: "SKIPPED", Template: "{{ (taskCounts $item).Skipped }}", Wide: true},
+	},
+	"TaskRun": {
+		nameColumn,
+		uidColumn,
+		{Name: "PIPELINERUN", Template: "{{ formatValue (index $item.Labels \"tekton.dev/pipelineRun\") }}"},
+		{Name: "TASK", Template: "{{ formatValue (index $item.Labels \"tekton.dev/pipelineTask\") }}"},
+		startedColumn,
+		durationColumn,
+		statusColumn,
+		{Name: "POD", Template: "{{ formatValue $item.Status.PodName }}", Wide: true},
+		{Name: "RETRIES", Template: "{{ len $item.Status.RetriesStatus }}", Wide: true},
+	},
+}
+
+// columns returns the columns to print for a kind
+func columns(kind string, o *Options) []Column {
+	t, ok := tables[kind]
+	if !ok {
+		t = tables[""]
+	}
+	var c []Column
+	if o.AllNamespaces {
+		c = append(c, namespaceColumn)
+	}
+	for _, column := range t {
+		if !column.Wide || o.Wide {
+			c = append(c, column)
+		}
+	}
+	return c
+}
+
+// listTemplate builds the template to print a list as table with the columns
+func listTemplate(c []Column) string {
+	names := make([]string, len(c))
+	values := make([]string, len(c))
+	for i, column := range c {
+		names[i] = column.Name
+		values[i] = column.Template
+	}
+
+	return `{{- $length := len .List.Items -}}{{- if eq $length 0 -}}
+No {{ or .List.Kind "resources" }} found
 {{ else -}}
 {{- if not $.NoHeaders -}}
-{{- if $.AllNamespaces -}}
-NAMESPACE	NAME	UID	STARTED	DURATION	STATUS
-{{ else -}}
-NAME	UID	STARTED	DURATION	STATUS
+` + strings.Join(names, "\t") + `
 {{ end -}}
-{{- end -}}
-{{- range $_, $item := .List.Items }}{{- if $item }}{{- if $.AllNamespaces -}}
-{{ $item.Namespace }}	{{ $item.Name }}	{{ $item.UID }}	{{ formatAge $item.Status.StartTime $.Time }}	{{ formatDuration $item.Status.StartTime $item.Status.CompletionTime }}	{{ formatCondition $item.Status.Conditions }}	{{ $item.UID }}
-{{ else -}}
-{{ $item.Name }}	{{ $item.UID }}	{{ formatAge $item.Status.StartTime $.Time }}	{{ formatDuration $item.Status.StartTime $item.Status.CompletionTime }}	{{ formatCondition $item.Status.Conditions }}
-{{ end -}}{{- end -}}{{- end -}}
+{{- range $_, $item := .List.Items }}{{- if $item -}}
+` + strings.Join(values, "\t") + `
+{{ end -}}{{- end -}}
 {{- end -}}`
+}
@@ -9,13 +9,27 @@ import (
 type List struct {
 	runtime.TypeMeta `json:",inline"`
 	NextPageToken    string `json:"nextPageToken,omitempty" yaml:"nextPageToken,omitempty"`
-	Items            []struct {
-		metav1.TypeMeta   `json:",inline"`
-		metav1.ObjectMeta `json:"metadata,omitempty"`
-		Status            struct {
-			v1.Status      `json:",inline"`
-			StartTime      *metav1.Time `json:"startTime,omitempty"`
-			CompletionTime *metav1.Time `json:"completionTime,omitempty"`
-		} `json:"status,omitempty"`
-	} `json:"items"`
+	Items            []Item `json:"items"`
+}
+
+// Item holds the fields of PipelineRuns, TaskRuns and other runs used in tables.
+type Item struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              struct {
+		PipelineRef *struct {
+			Name     string `json:"name,omitempty"`
+			Resolver string `json:"resolver,omitempty"`
+		} `json:"pipelineRef,omitempty"`
+	} `json:"spec,omitempty"`
+	Status struct {
+		v1.Status      `json:",inline"`
+		StartTime      *metav1.Time  `json:"startTime,omitempty"`
+		CompletionTime *metav1.Time  `json:"completionTime,omitempty"`
+		PodName        string        `json:"podName,omitempty"`
+		RetriesStatus  []interface{} `json:"retriesStatus,omitempty"`
+		SkippedTasks   []struct {
+			Name string `json:"name"`
+		} `json:"skippedTasks,omitempty"`
+	} `json:"status,omitempty"`
 }
@@ -0,0 +1,245 @@
+package action
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/sayan-biswas/kubectl-tekton/internal/helper"
+	"github.com/sayan-biswas/kubectl-tekton/internal/results/client"
+	results "github.com/tektoncd/results/proto/v1alpha2/results_go_proto"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"io"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// BackupEntry is a result or a record stored in a backup, along with the log
+// bytes for the records of logs in newline-delimited JSON backups.
+type BackupEntry struct {
+	Kind        string            `json:"kind"`
+	Run         string            `json:"run,omitempty"`
+	Name        string            `json:"name"`
+	UID         string            `json:"uid,omitempty"`
+	Etag        string            `json:"etag,omitempty"`
+	CreateTime  *time.Time        `json:"createTime,omitempty"`
+	UpdateTime  *time.Time        `json:"updateTime,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+	Summary     json.RawMessage   `json:"summary,omitempty"`
+	Type        string            `json:"type,omitempty"`
+	Data        json.RawMessage   `json:"data,omitempty"`
+	Log         []byte            `json:"log,omitempty"`
+
+	// archive is the tar archive of a backup holding the log of the entry
+	archive string
+}
+
+// hasLog checks if the log of the entry is in the backup
+func (e *BackupEntry) hasLog() bool {
+	return e.Kind == KindLog && (len(e.Log) > 0 || e.archive != "")
+}
+
+// Backup fetches the result or record of an entry before it is removed. Nil is
+// returned for entries which are already removed.
+func Backup(c client.Client, d Deletion) (*BackupEntry, error) {
+	e := &BackupEntry{
+		Kind: d.Kind,
+		Run:  d.Run,
+		Name: d.Name,
+	}
+
+	if d.Kind == KindResult {
+		r, err := c.GetResult(context.Background(), &results.GetResultRequest{
+			Name: d.Name,
+		})
+		if client.Status(err) == http.StatusNotFound {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		e.UID = r.GetUid()
+		e.Etag = r.GetEtag()
+		e.CreateTime = asTime(r.GetCreateTime())
+		e.UpdateTime = asTime(r.GetUpdateTime())
+		e.Annotations = r.GetAnnotations()
+		if r.GetSummary() != nil {
+			if e.Summary, err = protojson.Marshal(r.GetSummary()); err != nil {
+				return nil, err
+			}
+		}
+		return e, nil
+	}
+
+	r, err := c.GetRecord(context.Background(), &results.GetRecordRequest{
+		Name: d.Name,
+	})
+	if client.Status(err) == http.StatusNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	e.UID = r.GetUid()
+	e.Etag = r.GetEtag()
+	e.CreateTime = asTime(r.GetCreateTime())
+	e.UpdateTime = asTime(r.GetUpdateTime())
+	e.Type = r.GetData().GetType()
+	if v := r.GetData().GetValue(); len(v) > 0 {
+		if !json.Valid(v) {
+			return nil, fmt.Errorf("data of record %s is not valid JSON", d.Name)
+		}
+		e.Data = v
+	}
+
+	return e, nil
+}
+
+// backupLog returns a function streaming the log of an entry, or nil for
+// entries which are not records of logs.
+func backupLog(c client.Client, e *BackupEntry) func(w io.Writer) error {
+	if e.Kind != KindLog {
+		return nil
+	}
+	return func(w io.Writer) error {
+		err := Log(c, &Options{
+			ObjectMeta: metav1.ObjectMeta{Name: logName(e.Name)},
+		}, w)
+		// the record of a log exists before the log is stored
+		if err != nil && client.Status(err) != http.StatusNotFound {
+			return err
+		}
+		return nil
+	}
+}
+
+// asTime converts a protobuf timestamp, leaving unset timestamps nil
+func asTime(t *timestamppb.Timestamp) *time.Time {
+	if t == nil {
+		return nil
+	}
+	tt := t.AsTime()
+	return &tt
+}
+
+// BackupWriter stores the entries of a backup, and is safe for concurrent use.
+// Write returns once the entry is committed to storage, so the entry can be
+// removed when the write succeeds. The log of the records of logs is streamed
+// by the log function, when set.
+type BackupWriter interface {
+	Write(e *BackupEntry, log func(w io.Writer) error) error
+	Close() error
+}
+
+// NewBackupWriter returns a BackupWriter for the file. Files named .tar,
+// .tar.gz or .tgz are written as tar archives, with the entries as
+// <name>.json and the logs streamed to <name>.log, otherwise the entries are
+// written as newline-delimited JSON. Newline-delimited JSON holds each log in
+// memory and stores it base64 encoded in the line of the entry, so tar
+// archives are preferred for large logs.
+func NewBackupWriter(path string) (BackupWriter, error) {
+	if isTar(path) {
+		a, err := helper.NewTarArchive(path)
+		if err != nil {
+			return nil, err
+		}
+		return &tarBackup{archive: a}, nil
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, err
+	}
+	return &jsonBackup{file: f, encoder: json.NewEncoder(f)}, nil
+}
+
+func isTar(path string) bool {
+	for _, ext := range []string{".tar", ".tar.gz", ".tgz"} {
+		if strings.HasSuffix(path, ext) {
+			return true
+		}
+	}
+	return false
+}
+
+type jsonBackup struct {
+	sync.Mutex
+	file    *os.File
+	encoder *json.Encoder
+}
+
+func (b *jsonBackup) Write(e *BackupEntry, log func(w io.Writer) error) error {
+	if log != nil {
+		var buf bytes.Buffer
+		if err := log(&buf); err != nil {
+			return err
+		}
+		e.Log = buf.Bytes()
+	}
+
+	b.Lock()
+	defer b.Unlock()
+	if err := b.encoder.Encode(e); err != nil {
+		return err
+	}
+	return b.file.Sync()
+}
+
+func (b *jsonBackup) Close() error {
+	return b.file.Close()
+}
+
+type tarBackup struct {
+	sync.Mutex
+	archive helper.Archive
+}
+
+func (b *tarBackup) Write(e *BackupEntry, log func(w io.Writer) error) error {
+	m := *e
+	m.Log = nil
+	data, err := json.Marshal(&m)
+	if err != nil {
+		return err
+	}
+
+	// the log is spooled to a temporary file, as the size is written to the
+	// archive before the data, and concurrent writes are not blocked meanwhile
+	var f *os.File
+	if log != nil {
+		if f, err = os.CreateTemp("", "backup-*.log"); err != nil {
+			return err
+		}
+		defer os.Remove(f.Name())
+		defer f.Close()
+		if err := log(f); err != nil {
+			return err
+		}
+	}
+
+	b.Lock()
+	defer b.Unlock()
+	if err := b.archive.WriteFile(e.Name+".json", data); err != nil {
+		return err
+	}
+	if f != nil {
+		fi, err := f.Stat()
+		if err != nil {
+			return err
+		}
+		if _, err := f.Seek(0, io.SeekStart); err != nil {
+			return err
+		}
+		if err := b.archive.Copy(e.Name+".log", f, fi.Size()); err != nil {
+			return err
+		}
+	}
+	return b.archive.Sync()
+}
+
+func (b *tarBackup) Close() error {
+	return b.archive.Close()
+}
@@ -0,0 +1,95 @@
+package action
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// failingBackup fails to store the entries of a result
+type failingBackup struct {
+	result string
+}
+
+func (b *failingBackup) Write(e *BackupEntry, _ func(w io.Writer) error) error {
+	if strings.HasPrefix(e.Name, b.result) {
+		return errors.New("no space left on device")
+	}
+	return nil
+}
+
+func (b *failingBackup) Close() error {
+	return nil
+}
+
+func TestRemoveAllBackupFailure(t *testing.T) {
+	s, c := newServer(t, map[string]string{
+		"ns/results/a":           `{"name":"ns/results/a"}`,
+		"ns/results/a/records/1": record("ns/results/a/records/1"),
+		"ns/results/b":           `{"name":"ns/results/b"}`,
+		"ns/results/b/records/2": record("ns/results/b/records/2"),
+	})
+	ds := []Deletion{
+		{Kind: "TaskRun", Name: "ns/results/a/records/1"},
+		{Kind: "TaskRun", Name: "ns/results/b/records/2"},
+		{Kind: KindResult, Name: "ns/results/a"},
+		{Kind: KindResult, Name: "ns/results/b"},
+	}
+
+	statuses := RemoveAll(c, ds, &RemoveOptions{Parallelism: 2, Backup: &failingBackup{result: "ns/results/b"}})
+	for _, st := range statuses {
+		failed := strings.HasPrefix(st.Name, "ns/results/b")
+		if (st.Error != "") != failed {
+			t.Errorf("status of %s = %d %q", st.Name, st.Status, st.Error)
+		}
+	}
+	if _, ok := s.items["ns/results/b/records/2"]; !ok {
+		t.Error("record deleted without a backup")
+	}
+	if _, ok := s.items["ns/results/a"]; ok {
+		t.Error("result with a backup not deleted")
+	}
+}
+
+func TestTarBackupWriteIsFlushed(t *testing.T) {
+	_, c := newServer(t, map[string]string{
+		"ns/results/a/records/1": record("ns/results/a/records/1"),
+	})
+	path := filepath.Join(t.TempDir(), "backup.tar.gz")
+	w, err := NewBackupWriter(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Close()
+
+	e, err := Backup(c, Deletion{Kind: "TaskRun", Name: "ns/results/a/records/1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Write(e, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	// the entry is readable from the file before the backup is closed
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	gr, err := gzip.NewReader(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	h, err := tar.NewReader(gr).Next()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if h.Name != "ns/results/a/records/1.json" {
+		t.Errorf("entry = %s", h.Name)
+	}
+}
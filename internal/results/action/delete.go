@@ -3,15 +3,87 @@ package action
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"github.com/sayan-biswas/kubectl-tekton/internal/results/client"
 	"github.com/tektoncd/results/pkg/watcher/reconciler/annotation"
 	results "github.com/tektoncd/results/proto/v1alpha2/results_go_proto"
+	"golang.org/x/sync/errgroup"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"net/http"
+	"path"
+	"slices"
+	"strings"
+	"sync"
 )
 
-func Delete(c client.Client, o *Options) error {
-	// Delete child resources recursively
+// Kinds of the deleted entries, besides the kinds of the runs
+const (
+	KindLog       = "Log"
+	KindEventList = "EventList"
+	KindResult    = "Result"
+)
+
+// Deletion is an entry removed from tekton results when deleting a run.
+type Deletion struct {
+	// Kind is the kind of the run for records of runs, or the kind of the entry.
+	Kind string `json:"kind"`
+	// Run is the namespaced name of the run which the entry belongs to.
+	Run  string `json:"run"`
+	Name string `json:"name"`
+}
+
+// Deletions lists the records, logs and event lists removed when deleting a
+// run, children first. Results are added with AddResults, once the entries
+// of all the deleted runs are known.
+func Deletions(c client.Client, o *Options) ([]Deletion, error) {
+	return deletions(c, o)
+}
+
+// AddResults adds the results of the entries, after the entries, which are
+// removed when no other records are left in the result.
+func AddResults(c client.Client, ds []Deletion) ([]Deletion, error) {
+	names := map[string]bool{}
+	var parents []Deletion
+	for _, d := range ds {
+		names[d.Name] = true
+		parent, _, ok := strings.Cut(d.Name, "/records/")
+		if ok && !slices.ContainsFunc(parents, func(p Deletion) bool { return p.Name == parent }) {
+			parents = append(parents, Deletion{Kind: KindResult, Run: d.Run, Name: parent})
+		}
+	}
+
+	for _, p := range parents {
+		covered := true
+		for token, nextPage := "", true; nextPage && covered; {
+			lrr, err := c.ListRecords(context.Background(), &results.ListRecordsRequest{
+				Parent:    p.Name,
+				PageToken: token,
+			})
+			if err != nil {
+				return nil, err
+			}
+			for _, record := range lrr.Records {
+				if !names[record.Name] {
+					covered = false
+					break
+				}
+			}
+			token = lrr.NextPageToken
+			nextPage = token != ""
+		}
+		if covered {
+			ds = append(ds, p)
+		}
+	}
+	return ds, nil
+}
+
+// deletions lists the records of a run and of its children recursively
+func deletions(c client.Client, o *Options) ([]Deletion, error) {
+	var ds []Deletion
+
+	// List child resources recursively
 	if a, ok := o.Annotations[annotation.Result]; ok {
 		o := &Options{
 			ObjectMeta: metav1.ObjectMeta{
@@ -27,24 +99,32 @@ func Delete(c client.Client, o *Options) error {
 				PageToken: o.ListOptions.Continue,
 			})
 			if err != nil {
-				return err
+				return nil, err
 			}
 			for _, record := range lrr.Records {
 				m := new(struct {
+					metav1.TypeMeta   `json:",inline"`
 					metav1.ObjectMeta `json:"metadata,omitempty"`
 				})
 				err := json.Unmarshal(record.Data.Value, m)
 				if err != nil {
-					return err
+					return nil, err
 				}
-				if err := Delete(c, &Options{
+				child, err := deletions(c, &Options{
+					ListOptions: metav1.ListOptions{
+						TypeMeta: m.TypeMeta,
+					},
 					ObjectMeta: metav1.ObjectMeta{
+						Name:        m.Name,
+						Namespace:   m.Namespace,
 						UID:         m.UID,
 						Annotations: m.Annotations,
 					},
-				}); err != nil {
-					return err
+				})
+				if err != nil {
+					return nil, err
 				}
+				ds = append(ds, child...)
 			}
 			if nextPage = lrr.NextPageToken != ""; nextPage {
 				o.ListOptions.Continue = lrr.NextPageToken
@@ -52,30 +132,160 @@ func Delete(c client.Client, o *Options) error {
 		}
 	}
 
-	//Delete record entries
+	run := path.Join(o.Namespace, o.Name)
 	if a, ok := o.Annotations[annotation.Record]; ok {
-		if _, err := c.DeleteRecord(context.Background(), &results.DeleteRecordRequest{
-			Name: a,
-		}); err != nil && client.Status(err) != http.StatusNotFound {
-			return err
+		ds = append(ds, Deletion{Kind: o.Kind, Run: run, Name: a})
+	}
+	if a, ok := o.Annotations[annotation.Log]; ok {
+		ds = append(ds, Deletion{Kind: KindLog, Run: run, Name: logRecord(a)})
+	}
+	if a, ok := o.Annotations[annotation.EventList]; ok {
+		ds = append(ds, Deletion{Kind: KindEventList, Run: run, Name: a})
+	}
+	return ds, nil
+}
+
+// Delete removes a run from tekton results, along with its children, logs,
+// event lists and result. Failures don't stop the removal of other entries
+// and are returned together.
+func Delete(c client.Client, o *Options) error {
+	ds, err := Deletions(c, o)
+	if err != nil {
+		return err
+	}
+	if ds, err = AddResults(c, ds); err != nil {
+		return err
+	}
+	var errs []error
+	for _, s := range RemoveAll(c, ds, &RemoveOptions{Parallelism: 1}) {
+		if s.Error != "" {
+			errs = append(errs, fmt.Errorf("%s %s: %s", s.Kind, s.Name, s.Error))
 		}
 	}
+	return errors.Join(errs...)
+}
 
-	//Delete result entries if all child records are deleted
-	if a, ok := o.Annotations[annotation.Result]; ok {
-		if lrr, err := c.ListRecords(context.Background(), &results.ListRecordsRequest{
-			Parent: a,
-		}); err != nil {
-			return err
-		} else if len(lrr.Records) != 0 {
-			return nil
+// Remove deletes a single entry, entries already removed are ignored.
+func Remove(c client.Client, d Deletion) error {
+	var err error
+	switch d.Kind {
+	case KindResult:
+		_, err = c.DeleteResult(context.Background(), &results.DeleteResultRequest{
+			Name: d.Name,
+		})
+	default:
+		_, err = c.DeleteRecord(context.Background(), &results.DeleteRecordRequest{
+			Name: d.Name,
+		})
+	}
+	if err != nil && client.Status(err) != http.StatusNotFound {
+		return err
+	}
+	return nil
+}
+
+// logRecord returns the name of the record which stores a log
+func logRecord(name string) string {
+	s := strings.Split(name, "/")
+	if len(s) == 5 && s[1] == "results" && s[3] == "logs" {
+		s[3] = "records"
+	}
+	return strings.Join(s, "/")
+}
+
+// DeletionStatus is the outcome of removing an entry, with the HTTP status of
+// the response or the HTTP equivalent of the gRPC status.
+type DeletionStatus struct {
+	Deletion
+	Status int    `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+// RemoveOptions controls the removal of multiple entries.
+type RemoveOptions struct {
+	// Parallelism is the number of entries removed concurrently.
+	Parallelism int
+	// Progress, when set, is called after each entry.
+	Progress func(done, total int)
+	// Backup, when set, stores each entry before it is removed. Entries which
+	// failed to be stored are not removed.
+	Backup BackupWriter
+}
+
+// RemoveAll removes the entries concurrently and continues past failures.
+// Results are removed after the other entries, and skipped when other entries
+// of the result failed to be removed.
+func RemoveAll(c client.Client, ds []Deletion, o *RemoveOptions) []DeletionStatus {
+	statuses := make([]DeletionStatus, len(ds))
+	var mu sync.Mutex
+	done := 0
+
+	remove := func(i int, err error) {
+		statuses[i] = DeletionStatus{Deletion: ds[i], Status: http.StatusOK}
+		if err != nil {
+			statuses[i].Status = client.Status(err)
+			statuses[i].Error = err.Error()
 		}
-		if _, err := c.DeleteResult(context.Background(), &results.DeleteResultRequest{
-			Name: a,
-		}); err != nil && client.Status(err) != http.StatusNotFound {
-			return err
+		mu.Lock()
+		defer mu.Unlock()
+		done++
+		if o.Progress != nil {
+			o.Progress(done, len(ds))
 		}
 	}
 
-	return nil
+	g := errgroup.Group{}
+	g.SetLimit(max(o.Parallelism, 1))
+	for i := range ds {
+		if ds[i].Kind != KindResult {
+			g.Go(func() error {
+				remove(i, o.remove(c, ds[i]))
+				return nil
+			})
+		}
+	}
+	_ = g.Wait()
+
+	for i := range ds {
+		if ds[i].Kind != KindResult {
+			continue
+		}
+		var failed bool
+		for _, s := range statuses {
+			if s.Kind != KindResult && s.Error != "" && strings.HasPrefix(s.Name, ds[i].Name+"/") {
+				failed = true
+				break
+			}
+		}
+		if failed {
+			remove(i, nil)
+			statuses[i].Status = http.StatusFailedDependency
+			statuses[i].Error = "records of the result failed to be deleted"
+			continue
+		}
+		g.Go(func() error {
+			remove(i, o.remove(c, ds[i]))
+			return nil
+		})
+	}
+	_ = g.Wait()
+
+	return statuses
+}
+
+// remove stores the entry in the backup before removing it
+func (o *RemoveOptions) remove(c client.Client, d Deletion) error {
+	if o.Backup != nil {
+		e, err := Backup(c, d)
+		if err != nil {
+			return fmt.Errorf("backup failed: %w", err)
+		}
+		if e == nil {
+			return nil
+		}
+		if err := o.Backup.Write(e, backupLog(c, e)); err != nil {
+			return fmt.Errorf("backup failed: %w", err)
+		}
+	}
+	return Remove(c, d)
 }
@@ -0,0 +1,123 @@
+package action
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestAddResults(t *testing.T) {
+	items := map[string]string{
+		"ns/results/a":           `{"name":"ns/results/a"}`,
+		"ns/results/a/records/1": record("ns/results/a/records/1"),
+		"ns/results/a/records/2": record("ns/results/a/records/2"),
+		"ns/results/b":           `{"name":"ns/results/b"}`,
+		"ns/results/b/records/3": record("ns/results/b/records/3"),
+		"ns/results/b/records/4": record("ns/results/b/records/4"),
+	}
+	_, c := newServer(t, items)
+
+	tests := []struct {
+		name string
+		ds   []Deletion
+		want []string
+	}{
+		{
+			name: "records of a single run",
+			ds: []Deletion{
+				{Kind: "PipelineRun", Run: "ns/pr", Name: "ns/results/a/records/1"},
+				{Kind: "TaskRun", Run: "ns/tr", Name: "ns/results/a/records/2"},
+			},
+			want: []string{"ns/results/a"},
+		},
+		{
+			name: "records of multiple runs sharing a result",
+			ds: []Deletion{
+				{Kind: "TaskRun", Run: "ns/tr-1", Name: "ns/results/b/records/3"},
+				{Kind: "TaskRun", Run: "ns/tr-2", Name: "ns/results/b/records/4"},
+			},
+			want: []string{"ns/results/b"},
+		},
+		{
+			name: "records left in the result",
+			ds: []Deletion{
+				{Kind: "TaskRun", Run: "ns/tr-1", Name: "ns/results/a/records/1"},
+				{Kind: "TaskRun", Run: "ns/tr-2", Name: "ns/results/b/records/3"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ds, err := AddResults(c, tt.ds)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			for _, d := range ds[len(tt.ds):] {
+				if d.Kind != KindResult {
+					t.Errorf("unexpected deletion %v", d)
+				}
+				got = append(got, d.Name)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("results = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRemoveAll(t *testing.T) {
+	s, c := newServer(t, map[string]string{
+		"ns/results/a":           `{"name":"ns/results/a"}`,
+		"ns/results/a/records/1": record("ns/results/a/records/1"),
+		"ns/results/a/records/2": record("ns/results/a/records/2"),
+		"ns/results/b":           `{"name":"ns/results/b"}`,
+		"ns/results/b/records/3": record("ns/results/b/records/3"),
+	})
+	s.fail = func(method, name string) int {
+		if method == http.MethodDelete && name == "ns/results/a/records/2" {
+			return http.StatusInternalServerError
+		}
+		return 0
+	}
+	ds := []Deletion{
+		{Kind: KindResult, Name: "ns/results/a"},
+		{Kind: "TaskRun", Name: "ns/results/a/records/1"},
+		{Kind: "TaskRun", Name: "ns/results/a/records/2"},
+		{Kind: KindResult, Name: "ns/results/b"},
+		{Kind: "TaskRun", Name: "ns/results/b/records/3"},
+		// already removed
+		{Kind: "TaskRun", Name: "ns/results/b/records/4"},
+	}
+
+	var progress []int
+	statuses := RemoveAll(c, ds, &RemoveOptions{
+		Parallelism: 3,
+		Progress: func(done, total int) {
+			progress = append(progress, done)
+		},
+	})
+
+	want := map[string]int{
+		"ns/results/a":           http.StatusFailedDependency,
+		"ns/results/a/records/1": http.StatusOK,
+		"ns/results/a/records/2": http.StatusInternalServerError,
+		"ns/results/b":           http.StatusOK,
+		"ns/results/b/records/3": http.StatusOK,
+		"ns/results/b/records/4": http.StatusOK,
+	}
+	for i, st := range statuses {
+		if st.Name != ds[i].Name || st.Status != want[st.Name] {
+			t.Errorf("status %d = %s %d, want %s %d", i, st.Name, st.Status, ds[i].Name, want[ds[i].Name])
+		}
+	}
+	if _, ok := s.items["ns/results/a"]; !ok {
+		t.Error("result deleted along with the records which failed to be deleted")
+	}
+	if n := len(s.deleted); n == 0 || s.deleted[n-1] != "ns/results/b" {
+		t.Errorf("deleted %v, want the result deleted after its records", s.deleted)
+	}
+	if len(progress) != len(ds) || progress[len(progress)-1] != len(ds) {
+		t.Errorf("progress %v, want %d steps", progress, len(ds))
+	}
+}
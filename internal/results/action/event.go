@@ -0,0 +1,35 @@
+package action
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/sayan-biswas/kubectl-tekton/internal/results/client"
+	"github.com/tektoncd/results/pkg/watcher/reconciler/annotation"
+	results "github.com/tektoncd/results/proto/v1alpha2/results_go_proto"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// Events gets the event list stored for a run, or nil when the run has no
+// stored events.
+func Events(c client.Client, u *unstructured.Unstructured) (*corev1.EventList, error) {
+	name := u.GetAnnotations()[annotation.EventList]
+	if name == "" {
+		return nil, nil
+	}
+
+	r, err := c.GetRecord(context.Background(), &results.GetRecordRequest{
+		Name: name,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	el := &corev1.EventList{}
+	if err := json.Unmarshal(r.GetData().GetValue(), el); err != nil {
+		return nil, err
+	}
+	el.APIVersion = corev1.SchemeGroupVersion.String()
+	el.Kind = "EventList"
+	return el, nil
+}
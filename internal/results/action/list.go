@@ -10,18 +10,7 @@ import (
 )
 
 func List(c client.Client, o *Options) (*unstructured.UnstructuredList, error) {
-	err := o.validate()
-	if err != nil {
-		return nil, err
-	}
-
-	lrr, err := c.ListRecords(context.Background(), &results.ListRecordsRequest{
-		Parent:    fmt.Sprintf("%s/results/-", o.Namespace),
-		Filter:    o.filter(),
-		OrderBy:   "update_time desc",
-		PageSize:  int32(o.ListOptions.Limit),
-		PageToken: o.ListOptions.Continue,
-	})
+	lrr, err := Records(c, o)
 	if err != nil {
 		return nil, err
 	}
@@ -34,15 +23,49 @@ func List(c client.Client, o *Options) (*unstructured.UnstructuredList, error) {
 		},
 	}
 
-	for _, r := range lrr.Records {
+	ul.Items, err = Decode(lrr.Records)
+	if err != nil {
+		return nil, err
+	}
+
+	return ul, nil
+}
+
+// Records lists a page of records without decoding the data.
+func Records(c client.Client, o *Options) (*results.ListRecordsResponse, error) {
+	err := o.validate()
+	if err != nil {
+		return nil, err
+	}
+
+	if o.OrderBy == "" {
+		o.OrderBy = "update_time desc"
+	}
+
+	parent := o.Parent
+	if parent == "" {
+		parent = fmt.Sprintf("%s/results/-", o.Namespace)
+	}
+
+	return c.ListRecords(context.Background(), &results.ListRecordsRequest{
+		Parent:    parent,
+		Filter:    o.filter(),
+		OrderBy:   o.OrderBy,
+		PageSize:  int32(o.ListOptions.Limit),
+		PageToken: o.ListOptions.Continue,
+	})
+}
+
+// Decode converts the data of records to unstructured items.
+func Decode(records []*results.Record) ([]unstructured.Unstructured, error) {
+	var items []unstructured.Unstructured
+	for _, r := range records {
 		d := r.GetData().GetValue()
 		u := new(unstructured.Unstructured)
-		err = json.Unmarshal(d, u)
-		if err != nil {
+		if err := json.Unmarshal(d, u); err != nil {
 			return nil, err
 		}
-		ul.Items = append(ul.Items, *u)
+		items = append(items, *u)
 	}
-
-	return ul, nil
+	return items, nil
 }
@@ -2,20 +2,139 @@ package action
 
 import (
 	"context"
+	"errors"
 	"github.com/sayan-biswas/kubectl-tekton/internal/results/client"
+	"github.com/tektoncd/results/pkg/watcher/reconciler/annotation"
+	resultsv1alpha2 "github.com/tektoncd/results/proto/v1alpha2/results_go_proto"
 	results "github.com/tektoncd/results/proto/v1alpha3/results_go_proto"
+	"google.golang.org/genproto/googleapis/api/httpbody"
+	"google.golang.org/grpc"
+	"io"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/klog/v2"
+	"net/http"
+	"strings"
+	"sync"
 )
 
-func Log(c client.Client, o *Options) ([]byte, error) {
-	glc, err := c.GetLog(context.Background(), &results.GetLogRequest{
-		Name: o.Name,
-	})
+// Logs API versions, in the order of preference
+const (
+	LogsV1alpha3 = "v1alpha3"
+	LogsV1alpha2 = "v1alpha2"
+)
+
+// logsVersions remembers the Logs API version served to each client
+var logsVersions sync.Map
+
+// LogName returns the name of the log stored for a run, from the log
+// annotation or else the record annotation of the run.
+func LogName(u *unstructured.Unstructured) string {
+	a := u.GetAnnotations()
+	name := a[annotation.Log]
+	if name == "" {
+		name = a[annotation.Record]
+	}
+	return logName(name)
+}
+
+// logName returns the name of the log stored by a record, as
+// <parent>/results/<result>/records/<record> is served as
+// <parent>/results/<result>/logs/<record>.
+func logName(record string) string {
+	s := strings.Split(record, "/")
+	if len(s) == 5 && s[1] == "results" && s[3] == "records" {
+		s[3] = "logs"
+	}
+	return strings.Join(s, "/")
+}
+
+// Log streams the log to the writer, chunk by chunk as received, until the
+// end of the stream. The v1alpha3 Logs API is used when served, otherwise the
+// v1alpha2 Logs API.
+func Log(c client.Client, o *Options, w io.Writer) error {
+	// the stream is canceled when the log fails to be written, so the
+	// response isn't left open
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	versions := []string{LogsV1alpha3, LogsV1alpha2}
+	if v, ok := logsVersions.Load(c); ok {
+		versions = []string{v.(string)}
+	}
+
+	// a log not found doesn't tell if a version is served, as the paths of
+	// versions which are not served are not found either. The version serving
+	// the log is remembered, which is the next version when the log is not
+	// found with the preferred one.
+	var err, notFound error
+	for _, v := range versions {
+		var glc grpc.ServerStreamingClient[httpbody.HttpBody]
+		var l *httpbody.HttpBody
+		glc, l, err = getLog(ctx, c, v, o.Name)
+		if unsupported(err) {
+			klog.V(4).Infof("%s Logs API failed: %v", v, err)
+			continue
+		}
+		if client.Status(err) == http.StatusNotFound {
+			klog.V(4).Infof("%s Logs API log not found: %v", v, err)
+			notFound = err
+			continue
+		}
+		if _, ok := logsVersions.LoadOrStore(c, v); !ok {
+			klog.V(2).Infof("Using %s Logs API", v)
+		}
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		for {
+			if _, err := w.Write(l.GetData()); err != nil {
+				return err
+			}
+			l, err = glc.Recv()
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+		}
+	}
+	if notFound != nil {
+		return notFound
+	}
+	return err
+}
+
+// getLog requests the log from a version of the Logs API and receives the
+// first chunk, as errors of streams are only returned when receiving.
+func getLog(ctx context.Context, c client.Client, version, name string) (grpc.ServerStreamingClient[httpbody.HttpBody], *httpbody.HttpBody, error) {
+	var glc grpc.ServerStreamingClient[httpbody.HttpBody]
+	var err error
+	switch version {
+	case LogsV1alpha2:
+		glc, err = c.LogsV1alpha2().GetLog(ctx, &resultsv1alpha2.GetLogRequest{
+			Name: name,
+		})
+	default:
+		glc, err = c.GetLog(ctx, &results.GetLogRequest{
+			Name: name,
+		})
+	}
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	l, err := glc.Recv()
-	if err != nil {
-		return nil, err
+	return glc, l, err
+}
+
+// unsupported checks if the error is caused by an API not served
+func unsupported(err error) bool {
+	if err == nil || errors.Is(err, io.EOF) {
+		return false
 	}
-	return l.GetData(), nil
+	s := client.Status(err)
+	return s == http.StatusNotImplemented || s == http.StatusMethodNotAllowed
 }
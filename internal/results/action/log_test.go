@@ -0,0 +1,90 @@
+package action
+
+import (
+	"bytes"
+	"errors"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/sayan-biswas/kubectl-tekton/internal/results/client"
+	"google.golang.org/grpc/codes"
+	grpcstatus "google.golang.org/grpc/status"
+	"io"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestLogVersion(t *testing.T) {
+	o := &Options{ObjectMeta: metav1.ObjectMeta{Name: "default/results/r/logs/l"}}
+	tests := []struct {
+		name string
+		// status of the v1alpha3 Logs API, the path is not found by default
+		v1alpha3 int
+		want     any
+	}{
+		{"path not found", 0, LogsV1alpha2},
+		{"unimplemented", http.StatusNotImplemented, LogsV1alpha2},
+		{"method not allowed", http.StatusMethodNotAllowed, LogsV1alpha2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, c := newServer(t, map[string]string{"default/results/r/logs/l": "line\n"})
+			requests := 0
+			s.fail = func(_, name string) int {
+				if strings.HasPrefix(name, "/v1alpha3/") {
+					requests++
+					return tt.v1alpha3
+				}
+				return 0
+			}
+
+			for range 2 {
+				var b bytes.Buffer
+				if err := Log(c, o, &b); err != nil {
+					t.Fatal(err)
+				}
+				if b.String() != "line\n" {
+					t.Errorf("Log() = %q", b.String())
+				}
+			}
+			if v, _ := logsVersions.Load(c); v != tt.want {
+				t.Errorf("Logs API version = %v, want %v", v, tt.want)
+			}
+			// the version is negotiated once
+			if requests != 1 {
+				t.Errorf("%d requests to the v1alpha3 Logs API, want 1", requests)
+			}
+		})
+	}
+
+	// a log missing from all versions is not found, and doesn't tell which
+	// version is served
+	_, c := newServer(t, map[string]string{})
+	if err := Log(c, o, io.Discard); client.Status(err) != http.StatusNotFound {
+		t.Errorf("Log() of a missing log = %v, want not found", err)
+	}
+	if v, ok := logsVersions.Load(c); ok {
+		t.Errorf("Logs API version of a missing log = %v", v)
+	}
+}
+
+func TestUnsupported(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"nil", nil, false},
+		{"end of stream", io.EOF, false},
+		{"not found", grpcstatus.Error(codes.NotFound, "log not found"), false},
+		{"http not found", &runtime.HTTPStatusError{HTTPStatus: http.StatusNotFound, Err: errors.New("not found")}, false},
+		{"unimplemented", grpcstatus.Error(codes.Unimplemented, "unknown service"), true},
+		{"method not allowed", &runtime.HTTPStatusError{HTTPStatus: http.StatusMethodNotAllowed, Err: errors.New("method")}, true},
+		{"internal", grpcstatus.Error(codes.Internal, "failed"), false},
+	}
+	for _, tt := range tests {
+		if got := unsupported(tt.err); got != tt.want {
+			t.Errorf("unsupported(%s) = %t, want %t", tt.name, got, tt.want)
+		}
+	}
+}
@@ -3,29 +3,89 @@ package action
 import (
 	"fmt"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"reflect"
 	"strings"
+	"time"
 )
 
+// Wildcard is used in place of a namespace to query across all namespaces.
+const Wildcard = "-"
+
 type Options struct {
 	metav1.ListOptions
 	metav1.ObjectMeta
-	Filter string
+	// Types selects records of multiple kinds, otherwise the kind is taken from the ListOptions.
+	Types []metav1.TypeMeta
+	// Parent selects records of a result, otherwise records of all the results in the namespace.
+	Parent  string
+	Filter  string
+	Status  []string
+	OrderBy string
+
+	// Time windows for record and run timestamps.
+	Created   TimeRange
+	Updated   TimeRange
+	Started   TimeRange
+	Completed TimeRange
+}
+
+// SetKinds selects the kinds of the records. A single kind is also used as the
+// kind of the list.
+func (o *Options) SetKinds(gvks []schema.GroupVersionKind) {
+	o.Types = nil
+	for _, gvk := range gvks {
+		v, k := gvk.ToAPIVersionAndKind()
+		o.Types = append(o.Types, metav1.TypeMeta{Kind: k, APIVersion: v})
+	}
+	if len(o.Types) == 1 {
+		o.TypeMeta = o.Types[0]
+	}
+}
+
+// TimeRange selects items with a timestamp between After and Before.
+// Either of the bounds can be nil.
+type TimeRange struct {
+	After  *metav1.Time
+	Before *metav1.Time
+}
+
+// filter returns the clauses for the given CEL timestamp expression.
+func (t TimeRange) filter(field string) []string {
+	const timestamp = "%s %s timestamp(\"%s\")"
+
+	var filters []string
+	if t.After != nil {
+		filters = append(filters, fmt.Sprintf(timestamp, field, ">=", t.After.UTC().Format(time.RFC3339)))
+	}
+	if t.Before != nil {
+		filters = append(filters, fmt.Sprintf(timestamp, field, "<=", t.Before.UTC().Format(time.RFC3339)))
+	}
+	return filters
 }
 
 func (o *Options) validate() error {
 	switch {
 	case o.Namespace == "":
-		o.Namespace = "-"
+		o.Namespace = Wildcard
+	}
+	if _, err := Reasons(o.Kind, o.Status); err != nil {
+		return err
+	}
+	for _, t := range o.Types {
+		if t.Kind == "" || t.APIVersion == "" {
+			return fmt.Errorf("invalid type %q", t.String())
+		}
 	}
 	return nil
 }
 
 func (o *Options) filter() string {
 	const (
-		contains = "data.metadata.%s.contains(\"%s\")"
-		equal    = "data.metadata.%s[\"%s\"]==\"%s\""
-		dataType = "data_type==\"%s.%s\""
+		contains  = "data.metadata.%s.contains(\"%s\")"
+		equal     = "data.metadata.%s[\"%s\"]==\"%s\""
+		dataType  = "data_type==\"%s.%s\""
+		dataTypes = "data_type in [%s]"
 	)
 
 	var filters []string
@@ -34,10 +94,33 @@ func (o *Options) filter() string {
 		filters = append(filters, o.Filter)
 	}
 
-	if o.Kind != "" && o.APIVersion != "" {
+	switch {
+	case len(o.Types) > 1:
+		var types, statuses []string
+		for _, t := range o.Types {
+			types = append(types, fmt.Sprintf("\"%s.%s\"", t.APIVersion, t.Kind))
+			if s, _ := statusFilter(t.Kind, o.Status); s != "" {
+				statuses = append(statuses, fmt.Sprintf("(%s && %s)", fmt.Sprintf(dataType, t.APIVersion, t.Kind), s))
+			}
+		}
+		filters = append(filters, fmt.Sprintf(dataTypes, strings.Join(types, ", ")))
+		if len(statuses) > 0 {
+			filters = append(filters, "("+strings.Join(statuses, " || ")+")")
+		}
+	case o.Kind != "" && o.APIVersion != "":
 		filters = append(filters, fmt.Sprintf(dataType, o.APIVersion, o.Kind))
+		fallthrough
+	default:
+		if s, _ := statusFilter(o.Kind, o.Status); s != "" {
+			filters = append(filters, s)
+		}
 	}
 
+	filters = append(filters, o.Created.filter("create_time")...)
+	filters = append(filters, o.Updated.filter("update_time")...)
+	filters = append(filters, o.Started.filter(status("startTime"))...)
+	filters = append(filters, o.Completed.filter(status("completionTime"))...)
+
 	// TODO: add support for other types
 	v := reflect.ValueOf(o.ObjectMeta)
 	for i := 0; i < v.NumField(); i++ {
@@ -49,7 +132,8 @@ func (o *Options) filter() string {
 		k := v.Type().Field(i).Type.Kind()
 		switch k {
 		case reflect.String:
-			if fmt.Sprintf("%v", value) != "" {
+			// wildcard namespace is already resolved by the parent
+			if s := fmt.Sprintf("%v", value); s != "" && s != Wildcard {
 				filters = append(filters, fmt.Sprintf(contains, name, value))
 			}
 		case reflect.Map:
@@ -84,3 +168,9 @@ func (o *Options) filter() string {
 	}
 	return strings.Join(filters, " && ")
 }
+
+// status returns a CEL expression for a timestamp field in the record status,
+// guarded against records which don't have the field set yet.
+func status(field string) string {
+	return fmt.Sprintf("has(data.status.%[1]s) && timestamp(data.status.%[1]s)", field)
+}
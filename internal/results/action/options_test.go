@@ -0,0 +1,91 @@
+package action
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"testing"
+	"time"
+)
+
+func TestOptionsFilter(t *testing.T) {
+	at := &metav1.Time{Time: time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)}
+	tr := metav1.TypeMeta{Kind: "TaskRun", APIVersion: "tekton.dev/v1"}
+	pr := metav1.TypeMeta{Kind: "PipelineRun", APIVersion: "tekton.dev/v1"}
+
+	tests := []struct {
+		name string
+		o    Options
+		want string
+	}{
+		{
+			name: "empty",
+			want: "",
+		},
+		{
+			name: "raw filter and kind",
+			o: Options{
+				Filter:      `data.metadata.name.startsWith("build")`,
+				ListOptions: metav1.ListOptions{TypeMeta: tr},
+			},
+			want: `data.metadata.name.startsWith("build") && data_type=="tekton.dev/v1.TaskRun"`,
+		},
+		{
+			name: "metadata",
+			o: Options{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:       "build",
+					Namespace:  Wildcard,
+					Labels:     map[string]string{"app": "web"},
+					Finalizers: []string{"chains"},
+				},
+			},
+			want: `data.metadata.name.contains("build") && data.metadata.labels["app"]=="web" && ` +
+				`data.metadata.finalizers.contains("chains")`,
+		},
+		{
+			name: "label without value",
+			o: Options{
+				ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{"chains": ""}},
+			},
+			want: `data.metadata.annotations.contains("chains")`,
+		},
+		{
+			name: "status",
+			o: Options{
+				ListOptions: metav1.ListOptions{TypeMeta: tr},
+				Status:      []string{"timeout"},
+			},
+			want: `data_type=="tekton.dev/v1.TaskRun" && data.status.conditions[0].reason in ["TaskRunTimeout"]`,
+		},
+		{
+			name: "status of multiple kinds",
+			o: Options{
+				Types:  []metav1.TypeMeta{pr, tr},
+				Status: []string{"succeeded"},
+			},
+			want: `data_type in ["tekton.dev/v1.PipelineRun", "tekton.dev/v1.TaskRun"] && ` +
+				`((data_type=="tekton.dev/v1.PipelineRun" && data.status.conditions[0].reason in ["Succeeded", "Completed"]) || ` +
+				`(data_type=="tekton.dev/v1.TaskRun" && data.status.conditions[0].reason in ["Succeeded"]))`,
+		},
+		{
+			name: "time ranges",
+			o: Options{
+				Created:   TimeRange{After: at},
+				Updated:   TimeRange{Before: at},
+				Started:   TimeRange{After: at, Before: at},
+				Completed: TimeRange{Before: at},
+			},
+			want: `create_time >= timestamp("2024-05-01T10:00:00Z") && ` +
+				`update_time <= timestamp("2024-05-01T10:00:00Z") && ` +
+				`has(data.status.startTime) && timestamp(data.status.startTime) >= timestamp("2024-05-01T10:00:00Z") && ` +
+				`has(data.status.startTime) && timestamp(data.status.startTime) <= timestamp("2024-05-01T10:00:00Z") && ` +
+				`has(data.status.completionTime) && timestamp(data.status.completionTime) <= timestamp("2024-05-01T10:00:00Z")`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.o.filter(); got != tt.want {
+				t.Errorf("filter() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package action
+
+import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sort"
+	"time"
+)
+
+// Labels of the pipeline and task which runs are grouped by when pruning
+const (
+	PipelineLabel    = "tekton.dev/pipeline"
+	TaskLabel        = "tekton.dev/task"
+	PipelineRunLabel = "tekton.dev/pipelineRun"
+)
+
+// PrunePolicy selects the runs kept in each group of runs
+type PrunePolicy struct {
+	// KeepLast keeps the latest runs of each group.
+	KeepLast int
+	// OlderThan keeps the runs created after this time.
+	OlderThan time.Time
+	// KeepFailed keeps the runs which didn't succeed.
+	KeepFailed bool
+}
+
+// PruneGroup counts the runs of a pipeline or a task
+type PruneGroup struct {
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	Total     int    `json:"total"`
+	Kept      int    `json:"kept"`
+	Pruned    int    `json:"pruned"`
+}
+
+// Prune groups the completed runs by their pipeline or task and returns the
+// groups along with the runs to prune by the policy. Runs which are not
+// completed yet and TaskRuns of PipelineRuns are never pruned, the latter are
+// pruned along with their PipelineRun.
+func Prune(items []unstructured.Unstructured, p *PrunePolicy) ([]PruneGroup, []unstructured.Unstructured) {
+	var keys []PruneGroup
+	groups := map[PruneGroup][]unstructured.Unstructured{}
+	for _, item := range items {
+		if _, ok := timestamp(&item, "status", "completionTime"); !ok {
+			continue
+		}
+		if item.GetKind() == "TaskRun" && item.GetLabels()[PipelineRunLabel] != "" {
+			continue
+		}
+		k := PruneGroup{
+			Kind:      item.GetKind(),
+			Namespace: item.GetNamespace(),
+			Name:      groupName(&item),
+		}
+		if _, ok := groups[k]; !ok {
+			keys = append(keys, k)
+		}
+		groups[k] = append(groups[k], item)
+	}
+
+	var pruned []unstructured.Unstructured
+	for i, k := range keys {
+		runs := groups[k]
+		sort.SliceStable(runs, func(i, j int) bool {
+			return runs[i].GetCreationTimestamp().Time.After(runs[j].GetCreationTimestamp().Time)
+		})
+		keys[i].Total = len(runs)
+		for j := range runs {
+			if j < p.KeepLast ||
+				(!p.OlderThan.IsZero() && runs[j].GetCreationTimestamp().Time.After(p.OlderThan)) ||
+				(p.KeepFailed && failed(&runs[j])) {
+				keys[i].Kept++
+				continue
+			}
+			keys[i].Pruned++
+			pruned = append(pruned, runs[j])
+		}
+	}
+
+	sort.SliceStable(keys, func(i, j int) bool {
+		if keys[i].Kind != keys[j].Kind {
+			return keys[i].Kind < keys[j].Kind
+		}
+		if keys[i].Namespace != keys[j].Namespace {
+			return keys[i].Namespace < keys[j].Namespace
+		}
+		return keys[i].Name < keys[j].Name
+	})
+	return keys, pruned
+}
+
+// groupName returns the pipeline or task of a run, from the labels or else the
+// reference. Runs of neither, like CustomRuns or runs of resolver references
+// without a name, are grouped by the generateName of the run, or else by its
+// name, so unrelated runs are never pruned together.
+func groupName(u *unstructured.Unstructured) string {
+	for _, l := range []string{PipelineLabel, TaskLabel} {
+		if name := u.GetLabels()[l]; name != "" {
+			return name
+		}
+	}
+	for _, ref := range []string{"pipelineRef", "taskRef"} {
+		if name, _, _ := unstructured.NestedString(u.Object, "spec", ref, "name"); name != "" {
+			return name
+		}
+	}
+	if name := u.GetGenerateName(); name != "" {
+		return name
+	}
+	return u.GetName()
+}
+
+// failed checks if a completed run didn't succeed
+func failed(u *unstructured.Unstructured) bool {
+	conditions, _, _ := unstructured.NestedSlice(u.Object, "status", "conditions")
+	for _, c := range conditions {
+		if m, ok := c.(map[string]interface{}); ok && m["type"] == "Succeeded" {
+			return m["status"] == "False"
+		}
+	}
+	return false
+}
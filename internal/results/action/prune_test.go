@@ -0,0 +1,108 @@
+package action
+
+import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// run returns a completed run created days ago, of a pipeline or task
+func run(kind, name, group string, days int, succeeded string) unstructured.Unstructured {
+	created := time.Date(2024, 5, 30, 0, 0, 0, 0, time.UTC).AddDate(0, 0, -days)
+	u := unstructured.Unstructured{Object: map[string]interface{}{
+		"kind": kind,
+		"metadata": map[string]interface{}{
+			"name":              name,
+			"namespace":         "default",
+			"creationTimestamp": created.Format(time.RFC3339),
+		},
+		"status": map[string]interface{}{
+			"completionTime": created.Add(time.Hour).Format(time.RFC3339),
+			"conditions": []interface{}{
+				map[string]interface{}{"type": "Succeeded", "status": succeeded},
+			},
+		},
+	}}
+	if group != "" {
+		u.SetLabels(map[string]string{PipelineLabel: group})
+	}
+	return u
+}
+
+func TestPrune(t *testing.T) {
+	running := run("PipelineRun", "running", "build", 0, "Unknown")
+	unstructured.RemoveNestedField(running.Object, "status", "completionTime")
+	child := run("TaskRun", "child", "", 5, "True")
+	child.SetLabels(map[string]string{PipelineRunLabel: "build-1"})
+	byRef := run("PipelineRun", "ref-1", "", 9, "True")
+	_ = unstructured.SetNestedField(byRef.Object, "deploy", "spec", "pipelineRef", "name")
+	// runs of neither a pipeline nor a task are grouped by their generateName
+	nightlyX := run("CustomRun", "nightly-x", "", 6, "True")
+	nightlyX.SetGenerateName("nightly-")
+	nightlyY := run("CustomRun", "nightly-y", "", 8, "True")
+	nightlyY.SetGenerateName("nightly-")
+	adhoc := run("CustomRun", "adhoc", "", 7, "True")
+
+	items := []unstructured.Unstructured{
+		run("PipelineRun", "build-1", "build", 1, "True"),
+		run("PipelineRun", "build-3", "build", 3, "False"),
+		run("PipelineRun", "build-2", "build", 2, "True"),
+		run("PipelineRun", "build-4", "build", 4, "True"),
+		running,
+		child,
+		byRef,
+		nightlyX,
+		adhoc,
+		nightlyY,
+	}
+
+	tests := []struct {
+		name   string
+		policy PrunePolicy
+		pruned []string
+		groups []PruneGroup
+	}{
+		{
+			name:   "keep last",
+			policy: PrunePolicy{KeepLast: 2},
+			pruned: []string{"build-3", "build-4"},
+			groups: []PruneGroup{
+				{Kind: "CustomRun", Namespace: "default", Name: "adhoc", Total: 1, Kept: 1},
+				{Kind: "CustomRun", Namespace: "default", Name: "nightly-", Total: 2, Kept: 2},
+				{Kind: "PipelineRun", Namespace: "default", Name: "build", Total: 4, Kept: 2, Pruned: 2},
+				{Kind: "PipelineRun", Namespace: "default", Name: "deploy", Total: 1, Kept: 1},
+			},
+		},
+		{
+			name:   "older than",
+			policy: PrunePolicy{OlderThan: time.Date(2024, 5, 27, 12, 0, 0, 0, time.UTC)},
+			pruned: []string{"build-3", "build-4", "ref-1", "nightly-x", "nightly-y", "adhoc"},
+		},
+		{
+			name:   "keep failed",
+			policy: PrunePolicy{KeepLast: 1, KeepFailed: true},
+			pruned: []string{"build-2", "build-4", "nightly-y"},
+		},
+		{
+			name:   "prune all",
+			policy: PrunePolicy{},
+			pruned: []string{"build-1", "build-2", "build-3", "build-4", "ref-1", "nightly-x", "nightly-y", "adhoc"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			groups, pruned := Prune(items, &tt.policy)
+			var names []string
+			for _, u := range pruned {
+				names = append(names, u.GetName())
+			}
+			if !reflect.DeepEqual(names, tt.pruned) {
+				t.Errorf("pruned %v, want %v", names, tt.pruned)
+			}
+			if tt.groups != nil && !reflect.DeepEqual(groups, tt.groups) {
+				t.Errorf("groups %+v, want %+v", groups, tt.groups)
+			}
+		})
+	}
+}
@@ -0,0 +1,409 @@
+package action
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/sayan-biswas/kubectl-tekton/internal/results/client"
+	results "github.com/tektoncd/results/proto/v1alpha2/results_go_proto"
+	"golang.org/x/sync/errgroup"
+	"google.golang.org/protobuf/encoding/protojson"
+	"io"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// logChunkSize is the size of the log chunks streamed to the Logs API
+const logChunkSize = 32 * 1024
+
+// ReadBackup reads the entries of a backup written by a BackupWriter. The logs
+// of tar archives are not read, but streamed from the archive when restored.
+func ReadBackup(path string) ([]*BackupEntry, error) {
+	if isTar(path) {
+		var es []*BackupEntry
+		logs := map[string]bool{}
+		err := readTar(path, func(h *tar.Header, r io.Reader) error {
+			switch {
+			case strings.HasSuffix(h.Name, ".json"):
+				e := new(BackupEntry)
+				if err := json.NewDecoder(r).Decode(e); err != nil {
+					return fmt.Errorf("%s: %w", h.Name, err)
+				}
+				es = append(es, e)
+			case strings.HasSuffix(h.Name, ".log") && h.Size > 0:
+				logs[strings.TrimSuffix(h.Name, ".log")] = true
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, e := range es {
+			if logs[e.Name] {
+				e.archive = path
+			}
+		}
+		return es, nil
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var es []*BackupEntry
+	d := json.NewDecoder(f)
+	for {
+		e := new(BackupEntry)
+		err := d.Decode(e)
+		if errors.Is(err, io.EOF) {
+			return es, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		es = append(es, e)
+	}
+}
+
+// readTar calls the function with the files of a tar backup, in order.
+func readTar(path string, file func(h *tar.Header, r io.Reader) error) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	var r io.Reader = f
+	if !strings.HasSuffix(path, ".tar") {
+		gr, err := gzip.NewReader(f)
+		if err != nil {
+			return err
+		}
+		defer gr.Close()
+		r = gr
+	}
+
+	tr := tar.NewReader(r)
+	for {
+		h, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := file(h, tr); err != nil {
+			return err
+		}
+	}
+}
+
+// RestoreStatus is the outcome of restoring an entry of a backup, with the
+// status 201 for entries created, 200 for entries which already exist, or
+// else the HTTP status of the failure.
+type RestoreStatus struct {
+	Kind   string `json:"kind"`
+	Run    string `json:"run,omitempty"`
+	Name   string `json:"name"`
+	Status int    `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+// RestoreOptions controls the restore of multiple entries.
+type RestoreOptions struct {
+	// Parallelism is the number of entries restored concurrently.
+	Parallelism int
+	// Progress, when set, is called after each entry.
+	Progress func(done, total int)
+}
+
+// archivedLog is a record restored without its log, which is streamed from
+// the archive of the backup afterward.
+type archivedLog struct {
+	i       int
+	created bool
+}
+
+// RestoreAll restores the entries concurrently and continues past failures.
+// Results are restored before the other entries. Logs of tar archives are
+// streamed from the archive after the records, in the order of the archive.
+func RestoreAll(c client.Client, es []*BackupEntry, o *RestoreOptions) []RestoreStatus {
+	statuses := make([]RestoreStatus, len(es))
+	var mu sync.Mutex
+	done := 0
+	archived := map[string]archivedLog{}
+
+	finish := func(i int, created bool, err error) {
+		switch {
+		case err != nil:
+			statuses[i].Status = client.Status(err)
+			statuses[i].Error = err.Error()
+		case created:
+			statuses[i].Status = http.StatusCreated
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		done++
+		if o.Progress != nil {
+			o.Progress(done, len(es))
+		}
+	}
+
+	restore := func(i int) {
+		e := es[i]
+		statuses[i] = RestoreStatus{Kind: e.Kind, Run: e.Run, Name: e.Name, Status: http.StatusOK}
+		if e.archive == "" {
+			created, err := Restore(c, e)
+			finish(i, created, err)
+			return
+		}
+		created, log, err := restoreRecord(c, e)
+		if err != nil || !log {
+			finish(i, created, err)
+			return
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		archived[e.Name] = archivedLog{i: i, created: created}
+	}
+
+	g := errgroup.Group{}
+	g.SetLimit(max(o.Parallelism, 1))
+	for _, results := range []bool{true, false} {
+		for i := range es {
+			if (es[i].Kind == KindResult) == results {
+				g.Go(func() error {
+					restore(i)
+					return nil
+				})
+			}
+		}
+		_ = g.Wait()
+	}
+
+	if len(archived) == 0 {
+		return statuses
+	}
+	// all the logs of a backup are in the same archive
+	var path string
+	for _, a := range archived {
+		path = es[a.i].archive
+		break
+	}
+	err := readTar(path, func(h *tar.Header, r io.Reader) error {
+		a, ok := archived[strings.TrimSuffix(h.Name, ".log")]
+		if !ok || !strings.HasSuffix(h.Name, ".log") {
+			return nil
+		}
+		delete(archived, es[a.i].Name)
+		finish(a.i, true, restoreLog(c, es[a.i], a.created, r))
+		return nil
+	})
+	if err == nil {
+		err = errors.New("log not found in the backup")
+	}
+	for _, a := range archived {
+		finish(a.i, false, restoreLog(c, es[a.i], a.created, errReader{err}))
+	}
+
+	return statuses
+}
+
+// errReader fails to read with the error
+type errReader struct {
+	err error
+}
+
+func (r errReader) Read([]byte) (int, error) {
+	return 0, r.err
+}
+
+// Restore creates the result or record of an entry, keeping the name, along
+// with the log for the records of logs in newline-delimited JSON backups.
+// Entries which already exist are skipped, unless the log of an existing
+// record of a log is missing, and true is returned when the entry or its log
+// is created.
+func Restore(c client.Client, e *BackupEntry) (bool, error) {
+	if e.Kind == KindResult {
+		return restoreResult(c, e)
+	}
+	created, log, err := restoreRecord(c, e)
+	if err != nil || !log {
+		return created, err
+	}
+	if err := restoreLog(c, e, created, bytes.NewReader(e.Log)); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// restoreRecord creates the record of an entry unless it exists. The log of a
+// record of a log is to be restored when the record is created, or when the
+// log of an existing record is missing.
+func restoreRecord(c client.Client, e *BackupEntry) (created, log bool, err error) {
+	_, err = c.GetRecord(context.Background(), &results.GetRecordRequest{
+		Name: e.Name,
+	})
+	if err == nil {
+		if !e.hasLog() {
+			return false, false, nil
+		}
+		ok, err := logExists(c, logName(e.Name))
+		return false, !ok && err == nil, err
+	}
+	if client.Status(err) != http.StatusNotFound {
+		return false, false, err
+	}
+
+	parent, _, ok := strings.Cut(e.Name, "/records/")
+	if !ok {
+		return false, false, fmt.Errorf("invalid record name %q", e.Name)
+	}
+	// the result is missing when it wasn't deleted along with the record
+	if _, err := restoreResult(c, &BackupEntry{Kind: KindResult, Name: parent}); err != nil {
+		return false, false, err
+	}
+
+	_, err = c.CreateRecord(context.Background(), &results.CreateRecordRequest{
+		Parent: parent,
+		Record: &results.Record{
+			Name: e.Name,
+			Data: &results.Any{
+				Type:  e.Type,
+				Value: e.Data,
+			},
+		},
+	})
+	if client.Status(err) == http.StatusConflict {
+		return false, false, nil
+	}
+	if err != nil {
+		return false, false, err
+	}
+	return true, e.hasLog(), nil
+}
+
+// restoreLog stores the log of a record. A record created without its log is
+// removed when the log fails to be stored, so the log is restored on the next
+// import.
+func restoreLog(c client.Client, e *BackupEntry, created bool, log io.Reader) error {
+	err := updateLog(c, logName(e.Name), log)
+	if err != nil && created {
+		_, _ = c.DeleteRecord(context.Background(), &results.DeleteRecordRequest{
+			Name: e.Name,
+		})
+	}
+	return err
+}
+
+// logExists checks if a log is stored, by receiving its first chunk
+func logExists(c client.Client, name string) (bool, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	glc, err := c.LogsV1alpha2().GetLog(ctx, &results.GetLogRequest{
+		Name: name,
+	})
+	if err == nil {
+		_, err = glc.Recv()
+	}
+	switch {
+	case err == nil:
+		return true, nil
+	case errors.Is(err, io.EOF), client.Status(err) == http.StatusNotFound:
+		return false, nil
+	default:
+		return false, err
+	}
+}
+
+// CheckRestore checks if the entries can be restored with the client. Logs are
+// restored with the streaming Logs API, which is not served over REST.
+func CheckRestore(c client.Client, es []*BackupEntry) error {
+	if !slices.ContainsFunc(es, (*BackupEntry).hasLog) {
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if _, err := c.LogsV1alpha2().UpdateLog(ctx); err != nil {
+		return fmt.Errorf("logs of the backup can't be imported: %w", err)
+	}
+	return nil
+}
+
+// restoreResult creates the result unless it exists
+func restoreResult(c client.Client, e *BackupEntry) (bool, error) {
+	_, err := c.GetResult(context.Background(), &results.GetResultRequest{
+		Name: e.Name,
+	})
+	if err == nil {
+		return false, nil
+	}
+	if client.Status(err) != http.StatusNotFound {
+		return false, err
+	}
+
+	parent, _, ok := strings.Cut(e.Name, "/results/")
+	if !ok {
+		return false, fmt.Errorf("invalid result name %q", e.Name)
+	}
+
+	r := &results.Result{
+		Name:        e.Name,
+		Annotations: e.Annotations,
+	}
+	if len(e.Summary) > 0 {
+		r.Summary = new(results.RecordSummary)
+		if err := protojson.Unmarshal(e.Summary, r.Summary); err != nil {
+			return false, err
+		}
+	}
+
+	_, err = c.CreateResult(context.Background(), &results.CreateResultRequest{
+		Parent: parent,
+		Result: r,
+	})
+	// records of the same result are restored concurrently
+	if client.Status(err) == http.StatusConflict {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// updateLog streams the log to the v1alpha2 Logs API in chunks
+func updateLog(c client.Client, name string, log io.Reader) error {
+	ulc, err := c.LogsV1alpha2().UpdateLog(context.Background())
+	if err != nil {
+		return err
+	}
+	buf := make([]byte, logChunkSize)
+	for {
+		n, err := io.ReadFull(log, buf)
+		if n > 0 {
+			if err := ulc.Send(&results.Log{
+				Name: name,
+				Data: buf[:n],
+			}); err != nil {
+				return err
+			}
+		}
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+	}
+	_, err = ulc.CloseAndRecv()
+	return err
+}
@@ -0,0 +1,138 @@
+package action
+
+import (
+	"encoding/json"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// source are the items of a server with a result of a TaskRun and its log
+func source() map[string]string {
+	return map[string]string{
+		"ns/results/a":           `{"name":"ns/results/a","annotations":{"team":"web"}}`,
+		"ns/results/a/records/1": record("ns/results/a/records/1"),
+		"ns/results/a/records/2": `{"name":"ns/results/a/records/2","data":{"type":"results.tekton.dev/v1alpha2.Log","value":"e30="}}`,
+		"ns/results/a/logs/2":    "[build] one\n[build] two\n",
+	}
+}
+
+func TestBackupRestore(t *testing.T) {
+	ds := []Deletion{
+		{Kind: "TaskRun", Name: "ns/results/a/records/1"},
+		{Kind: KindLog, Name: "ns/results/a/records/2"},
+		{Kind: KindResult, Name: "ns/results/a"},
+	}
+
+	for _, file := range []string{"backup.ndjson", "backup.tar", "backup.tar.gz"} {
+		t.Run(file, func(t *testing.T) {
+			_, c := newServer(t, source())
+			path := filepath.Join(t.TempDir(), file)
+			w, err := NewBackupWriter(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, d := range ds {
+				e, err := Backup(c, d)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if err := w.Write(e, backupLog(c, e)); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if err := w.Close(); err != nil {
+				t.Fatal(err)
+			}
+
+			es, err := ReadBackup(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(es) != len(ds) {
+				t.Fatalf("read %d entries, want %d", len(es), len(ds))
+			}
+			for _, e := range es {
+				if e.Kind == KindLog && !e.hasLog() {
+					t.Errorf("log of %s not in the backup", e.Name)
+				}
+				// logs of archives are streamed when restored
+				if isTar(path) && e.Log != nil {
+					t.Errorf("log of %s read from the archive", e.Name)
+				}
+				if e.Kind == KindResult && e.Annotations["team"] != "web" {
+					t.Errorf("annotations of %s = %v", e.Name, e.Annotations)
+				}
+			}
+
+			// logs are not restored over REST
+			s, dst := newServer(t, map[string]string{})
+			if err := CheckRestore(dst, es); err == nil {
+				t.Error("CheckRestore() of logs over REST succeeded")
+			}
+			lc := logsClient{dst, s}
+			if err := CheckRestore(lc, es); err != nil {
+				t.Fatal(err)
+			}
+
+			for i, want := range []int{http.StatusCreated, http.StatusOK} {
+				for _, st := range RestoreAll(lc, es, &RestoreOptions{Parallelism: 2}) {
+					if st.Status != want {
+						t.Errorf("import %d of %s = %d %s, want %d", i+1, st.Name, st.Status, st.Error, want)
+					}
+				}
+			}
+			for _, d := range ds {
+				if _, ok := s.items[d.Name]; !ok {
+					t.Errorf("%s not restored", d.Name)
+				}
+			}
+			if log := s.items["ns/results/a/logs/2"]; log != "[build] one\n[build] two\n" {
+				t.Errorf("restored log = %q", log)
+			}
+			r := struct {
+				Data struct {
+					Type  string `json:"type"`
+					Value []byte `json:"value"`
+				} `json:"data"`
+			}{}
+			if err := json.Unmarshal([]byte(s.items["ns/results/a/records/1"]), &r); err != nil {
+				t.Fatal(err)
+			}
+			if r.Data.Type != "tekton.dev/v1.TaskRun" || string(r.Data.Value) != "{}" {
+				t.Errorf("restored data = %s %s", r.Data.Type, r.Data.Value)
+			}
+		})
+	}
+}
+
+func TestRestoreExistingLog(t *testing.T) {
+	e := &BackupEntry{Kind: KindLog, Name: "ns/results/a/records/2", Log: []byte("one\n")}
+
+	// the log is stored, so the entry is skipped
+	_, c := newServer(t, source())
+	if created, err := Restore(c, e); created || err != nil {
+		t.Errorf("Restore() with the log = %t, %v", created, err)
+	}
+
+	// the record is left without its log, so the log is restored, which
+	// isn't served over REST
+	items := source()
+	delete(items, "ns/results/a/logs/2")
+	_, c = newServer(t, items)
+	if _, err := Restore(c, e); err == nil || !strings.Contains(err.Error(), "UpdateLog") {
+		t.Errorf("Restore() without the log = %v, want the log to be updated", err)
+	}
+}
+
+func TestUpdateLogChunks(t *testing.T) {
+	s, c := newServer(t, map[string]string{})
+	log := strings.Repeat("x", 2*logChunkSize+1)
+	if err := updateLog(logsClient{c, s}, "ns/results/a/logs/2", strings.NewReader(log)); err != nil {
+		t.Fatal(err)
+	}
+	if s.items["ns/results/a/logs/2"] != log {
+		t.Errorf("log of %d bytes, want %d", len(s.items["ns/results/a/logs/2"]), len(log))
+	}
+}
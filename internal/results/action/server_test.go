@@ -0,0 +1,159 @@
+package action
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/sayan-biswas/kubectl-tekton/internal/results/client"
+	resultsv1alpha2 "github.com/tektoncd/results/proto/v1alpha2/results_go_proto"
+	"google.golang.org/grpc"
+	"k8s.io/client-go/transport"
+)
+
+// server is an in-memory results API served over REST, with the results and
+// records stored as json by name.
+type server struct {
+	sync.Mutex
+	items map[string]string
+	// fail returns the status of failed requests, or zero
+	fail func(method, name string) int
+	// deleted are the names of the deleted items, in order
+	deleted []string
+}
+
+// newServer returns a REST client for a server with the items
+func newServer(t *testing.T, items map[string]string) (*server, client.Client) {
+	s := &server{items: items}
+	hs := httptest.NewServer(s)
+	t.Cleanup(hs.Close)
+
+	u, err := url.Parse(hs.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, err := client.NewClient(&client.Config{URL: u, Transport: &transport.Config{}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s, c
+}
+
+func (s *server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.Lock()
+	defer s.Unlock()
+
+	name := strings.TrimPrefix(r.URL.Path, "/parents/")
+	if s.fail != nil {
+		if status := s.fail(r.Method, name); status != 0 {
+			http.Error(w, http.StatusText(status), status)
+			return
+		}
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		if parent, ok := strings.CutSuffix(name, "/records"); ok {
+			var records []json.RawMessage
+			var names []string
+			for n := range s.items {
+				if strings.HasPrefix(n, parent+"/records/") {
+					names = append(names, n)
+				}
+			}
+			sort.Strings(names)
+			for _, n := range names {
+				records = append(records, json.RawMessage(s.items[n]))
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{"records": records})
+			return
+		}
+		item, ok := s.items[name]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = io.WriteString(w, item)
+	case http.MethodPost:
+		b, _ := io.ReadAll(r.Body)
+		m := struct {
+			Name string `json:"name"`
+		}{}
+		_ = json.Unmarshal(b, &m)
+		if _, ok := s.items[m.Name]; ok {
+			http.Error(w, "already exists", http.StatusConflict)
+			return
+		}
+		s.items[m.Name] = string(b)
+		_, _ = w.Write(b)
+	case http.MethodDelete:
+		if _, ok := s.items[name]; !ok {
+			http.NotFound(w, r)
+			return
+		}
+		delete(s.items, name)
+		// records of a result are deleted along with the result
+		for n := range s.items {
+			if strings.HasPrefix(n, name+"/") {
+				delete(s.items, n)
+			}
+		}
+		s.deleted = append(s.deleted, name)
+		_, _ = io.WriteString(w, "{}")
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// record returns the json of a record
+func record(name string) string {
+	return `{"name":"` + name + `","data":{"type":"tekton.dev/v1.TaskRun","value":"e30="}}`
+}
+
+// logsClient is a client storing logs in the server with a streaming UpdateLog,
+// which isn't served over REST.
+type logsClient struct {
+	client.Client
+	s *server
+}
+
+func (c logsClient) LogsV1alpha2() resultsv1alpha2.LogsClient {
+	return updateLogs{c.Client.LogsV1alpha2(), c.s}
+}
+
+type updateLogs struct {
+	resultsv1alpha2.LogsClient
+	s *server
+}
+
+func (l updateLogs) UpdateLog(context.Context, ...grpc.CallOption) (grpc.ClientStreamingClient[resultsv1alpha2.Log, resultsv1alpha2.LogSummary], error) {
+	return &updateLogClient{s: l.s}, nil
+}
+
+// updateLogClient stores the chunks of the log when the stream is closed
+type updateLogClient struct {
+	grpc.ClientStream
+	s    *server
+	name string
+	data []byte
+}
+
+func (u *updateLogClient) Send(l *resultsv1alpha2.Log) error {
+	u.name = l.Name
+	u.data = append(u.data, l.Data...)
+	return nil
+}
+
+func (u *updateLogClient) CloseAndRecv() (*resultsv1alpha2.LogSummary, error) {
+	u.s.Lock()
+	defer u.s.Unlock()
+	u.s.items[u.name] = string(u.data)
+	return &resultsv1alpha2.LogSummary{Record: u.name}, nil
+}
@@ -0,0 +1,116 @@
+package action
+
+import (
+	"cmp"
+	"fmt"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Keys accepted for sorting records.
+const (
+	SortCreated   = "created"
+	SortUpdated   = "updated"
+	SortStarted   = "started"
+	SortCompleted = "completed"
+	SortName      = "name"
+	SortDuration  = "duration"
+)
+
+// SortKeys lists all the supported sort keys.
+var SortKeys = []string{SortCreated, SortUpdated, SortStarted, SortCompleted, SortName, SortDuration}
+
+// orderBy maps sort keys to fields ordered by the server.
+var orderBy = map[string]string{
+	SortCreated: "create_time",
+	SortUpdated: "update_time",
+}
+
+// OrderBy returns the server side order for a sort key. An empty order is
+// returned for the keys which can only be sorted on the client side.
+func OrderBy(key string, ascending bool) (string, error) {
+	if key == "" {
+		key = SortUpdated
+	}
+	if !slices.Contains(SortKeys, key) {
+		return "", fmt.Errorf("invalid sort key %q, must be one of %s", key, strings.Join(SortKeys, ", "))
+	}
+	f, ok := orderBy[key]
+	if !ok {
+		return "", nil
+	}
+	if ascending {
+		return f + " asc", nil
+	}
+	return f + " desc", nil
+}
+
+// sortValue is the value of an item compared when sorting, durations are
+// compared as durations and the other values as strings.
+type sortValue struct {
+	s string
+	d time.Duration
+}
+
+// Sort orders items on the client side by the sort key.
+// Items without the sorted value are always placed at the end.
+func Sort(items []unstructured.Unstructured, key string, ascending bool) {
+	value := func(u *unstructured.Unstructured) (v sortValue, ok bool) {
+		switch key {
+		case SortName:
+			v.s, ok = u.GetName(), true
+		case SortCreated:
+			v.s, ok = timestamp(u, "metadata", "creationTimestamp")
+		case SortStarted:
+			v.s, ok = timestamp(u, "status", "startTime")
+		case SortCompleted:
+			v.s, ok = timestamp(u, "status", "completionTime")
+		case SortDuration:
+			v.d, ok = duration(u)
+		}
+		return v, ok
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		a, aok := value(&items[i])
+		b, bok := value(&items[j])
+		if !aok || !bok {
+			return aok
+		}
+		c := cmp.Or(cmp.Compare(a.d, b.d), strings.Compare(a.s, b.s))
+		if ascending {
+			return c < 0
+		}
+		return c > 0
+	})
+}
+
+// timestamp returns a RFC3339 timestamp field normalized to UTC for comparison
+func timestamp(u *unstructured.Unstructured, fields ...string) (string, bool) {
+	s, ok, _ := unstructured.NestedString(u.Object, fields...)
+	if !ok || s == "" {
+		return "", false
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return "", false
+	}
+	return t.UTC().Format(time.RFC3339), true
+}
+
+// duration returns the run duration, measured until now for unfinished runs
+func duration(u *unstructured.Unstructured) (time.Duration, bool) {
+	s, ok := timestamp(u, "status", "startTime")
+	if !ok {
+		return 0, false
+	}
+	start, _ := time.Parse(time.RFC3339, s)
+	end := time.Now()
+	if c, ok := timestamp(u, "status", "completionTime"); ok {
+		end, _ = time.Parse(time.RFC3339, c)
+	}
+	return end.Sub(start), true
+}
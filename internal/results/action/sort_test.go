@@ -0,0 +1,55 @@
+package action
+
+import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestSort(t *testing.T) {
+	// item returns a run started and completed at the times, unset when empty
+	item := func(name, start, end string) unstructured.Unstructured {
+		u := unstructured.Unstructured{Object: map[string]interface{}{}}
+		u.SetName(name)
+		if start != "" {
+			_ = unstructured.SetNestedField(u.Object, start, "status", "startTime")
+		}
+		if end != "" {
+			_ = unstructured.SetNestedField(u.Object, end, "status", "completionTime")
+		}
+		return u
+	}
+	items := []unstructured.Unstructured{
+		item("ten-seconds", "2024-05-01T10:00:00Z", "2024-05-01T10:00:10Z"),
+		item("unset", "", ""),
+		item("two-hours", "2024-05-01T08:00:00Z", "2024-05-01T10:00:00Z"),
+		// completed before started, e.g. by a clock skew
+		item("negative", "2024-05-01T10:00:05Z", "2024-05-01T10:00:00Z"),
+		item("more-negative", "2024-05-01T10:01:00Z", "2024-05-01T10:00:00Z"),
+		item("one-minute", "2024-05-01T10:00:00+03:00", "2024-05-01T10:01:00+03:00"),
+	}
+
+	tests := []struct {
+		key       string
+		ascending bool
+		want      []string
+	}{
+		{SortDuration, true, []string{"more-negative", "negative", "ten-seconds", "one-minute", "two-hours", "unset"}},
+		{SortDuration, false, []string{"two-hours", "one-minute", "ten-seconds", "negative", "more-negative", "unset"}},
+		{SortName, true, []string{"more-negative", "negative", "one-minute", "ten-seconds", "two-hours", "unset"}},
+		{SortStarted, true, []string{"one-minute", "two-hours", "ten-seconds", "negative", "more-negative", "unset"}},
+		{SortCompleted, false, []string{"ten-seconds", "two-hours", "negative", "more-negative", "one-minute", "unset"}},
+	}
+	for _, tt := range tests {
+		sorted := slices.Clone(items)
+		Sort(sorted, tt.key, tt.ascending)
+		var names []string
+		for _, u := range sorted {
+			names = append(names, u.GetName())
+		}
+		if !reflect.DeepEqual(names, tt.want) {
+			t.Errorf("Sort(%s, ascending %t) = %v, want %v", tt.key, tt.ascending, names, tt.want)
+		}
+	}
+}
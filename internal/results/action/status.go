@@ -0,0 +1,142 @@
+package action
+
+import (
+	"fmt"
+	pipelinev1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"slices"
+	"strings"
+)
+
+// Friendly status names accepted by the status filter.
+const (
+	StatusSucceeded = "succeeded"
+	StatusFailed    = "failed"
+	StatusCancelled = "cancelled"
+	StatusTimeout   = "timeout"
+	StatusRunning   = "running"
+)
+
+// Statuses lists all the supported status names.
+var Statuses = []string{StatusSucceeded, StatusFailed, StatusCancelled, StatusTimeout, StatusRunning}
+
+// reasons maps status names to condition reasons for each kind. Kinds not
+// listed here use the generic reasons defined for the empty kind.
+var reasons = map[string]map[string][]string{
+	"PipelineRun": {
+		StatusSucceeded: {
+			pipelinev1.PipelineRunReasonSuccessful.String(),
+			pipelinev1.PipelineRunReasonCompleted.String(),
+		},
+		StatusFailed: {
+			pipelinev1.PipelineRunReasonFailed.String(),
+			pipelinev1.PipelineRunReasonCouldntGetPipeline.String(),
+			pipelinev1.PipelineRunReasonInvalidBindings.String(),
+			pipelinev1.PipelineRunReasonInvalidWorkspaceBinding.String(),
+			pipelinev1.PipelineRunReasonInvalidTaskRunSpec.String(),
+			pipelinev1.PipelineRunReasonParameterTypeMismatch.String(),
+			pipelinev1.PipelineRunReasonObjectParameterMissKeys.String(),
+			pipelinev1.PipelineRunReasonParamArrayIndexingInvalid.String(),
+			pipelinev1.PipelineRunReasonCouldntGetTask.String(),
+			pipelinev1.PipelineRunReasonParameterMissing.String(),
+			pipelinev1.PipelineRunReasonFailedValidation.String(),
+			pipelinev1.PipelineRunReasonCouldntGetPipelineResult.String(),
+			pipelinev1.PipelineRunReasonInvalidGraph.String(),
+			pipelinev1.PipelineRunReasonCouldntCancel.String(),
+			pipelinev1.PipelineRunReasonCouldntTimeOut.String(),
+			pipelinev1.PipelineRunReasonInvalidMatrixParameterTypes.String(),
+			pipelinev1.PipelineRunReasonInvalidTaskResultReference.String(),
+			pipelinev1.PipelineRunReasonInvalidPipelineResultReference.String(),
+			pipelinev1.PipelineRunReasonRequiredWorkspaceMarkedOptional.String(),
+			pipelinev1.PipelineRunReasonResourceVerificationFailed.String(),
+			pipelinev1.PipelineRunReasonCreateRunFailed.String(),
+			pipelinev1.PipelineRunReasonCELEvaluationFailed.String(),
+			pipelinev1.PipelineRunReasonInvalidParamValue.String(),
+		},
+		StatusCancelled: {
+			pipelinev1.PipelineRunReasonCancelled.String(),
+			pipelinev1.PipelineRunReasonCancelledRunningFinally.String(),
+			pipelinev1.PipelineRunReasonStoppedRunningFinally.String(),
+		},
+		StatusTimeout: {
+			pipelinev1.PipelineRunReasonTimedOut.String(),
+		},
+		StatusRunning: {
+			pipelinev1.PipelineRunReasonStarted.String(),
+			pipelinev1.PipelineRunReasonRunning.String(),
+			pipelinev1.PipelineRunReasonPending.String(),
+			pipelinev1.PipelineRunReasonStopping.String(),
+			pipelinev1.PipelineRunReasonResolvingPipelineRef.String(),
+		},
+	},
+	"TaskRun": {
+		StatusSucceeded: {
+			pipelinev1.TaskRunReasonSuccessful.String(),
+		},
+		StatusFailed: {
+			pipelinev1.TaskRunReasonFailed.String(),
+			pipelinev1.TaskRunReasonImagePullFailed.String(),
+			pipelinev1.TaskRunReasonResultLargerThanAllowedLimit.String(),
+			pipelinev1.TaskRunReasonStopSidecarFailed.String(),
+			pipelinev1.TaskRunReasonInvalidParamValue.String(),
+			pipelinev1.TaskRunReasonFailedResolution.String(),
+			pipelinev1.TaskRunReasonFailedValidation.String(),
+			pipelinev1.TaskRunReasonTaskFailedValidation.String(),
+			pipelinev1.TaskRunReasonResourceVerificationFailed.String(),
+			pipelinev1.TaskRunReasonFailureIgnored.String(),
+		},
+		StatusCancelled: {
+			pipelinev1.TaskRunReasonCancelled.String(),
+		},
+		StatusTimeout: {
+			pipelinev1.TaskRunReasonTimedOut.String(),
+		},
+		StatusRunning: {
+			pipelinev1.TaskRunReasonStarted.String(),
+			pipelinev1.TaskRunReasonRunning.String(),
+			pipelinev1.TaskRunReasonToBeRetried.String(),
+			pipelinev1.TaskRunReasonResolvingTaskRef,
+			pipelinev1.TaskRunReasonResolvingStepActionRef,
+			"Pending",
+		},
+	},
+	"": {
+		StatusSucceeded: {"Succeeded"},
+		StatusFailed:    {"Failed"},
+		StatusCancelled: {"Cancelled", "CustomRunCancelled"},
+		StatusTimeout:   {"CustomRunTimedOut"},
+		StatusRunning:   {"Started", "Running", "Pending"},
+	},
+}
+
+// Reasons returns the condition reasons of a kind matching the status names.
+func Reasons(kind string, statuses []string) ([]string, error) {
+	m, ok := reasons[kind]
+	if !ok {
+		m = reasons[""]
+	}
+	var r []string
+	for _, s := range statuses {
+		s = strings.ToLower(strings.TrimSpace(s))
+		if s == "canceled" {
+			s = StatusCancelled
+		}
+		if !slices.Contains(Statuses, s) {
+			return nil, fmt.Errorf("invalid status %q, must be one of %s", s, strings.Join(Statuses, ", "))
+		}
+		r = append(r, m[s]...)
+	}
+	return r, nil
+}
+
+// statusFilter returns the clause matching the condition reasons of the status names.
+func statusFilter(kind string, statuses []string) (string, error) {
+	r, err := Reasons(kind, statuses)
+	if err != nil || len(r) == 0 {
+		return "", err
+	}
+	q := make([]string, len(r))
+	for i, v := range r {
+		q[i] = fmt.Sprintf("%q", v)
+	}
+	return fmt.Sprintf("data.status.conditions[0].reason in [%s]", strings.Join(q, ", ")), nil
+}
@@ -0,0 +1,89 @@
+package action
+
+import (
+	"github.com/sayan-biswas/kubectl-tekton/internal/results/client"
+	"github.com/tektoncd/results/pkg/watcher/reconciler/annotation"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sort"
+)
+
+// PipelineTaskLabel holds the name of the pipeline task which created a TaskRun.
+const PipelineTaskLabel = "tekton.dev/pipelineTask"
+
+// taskRunKinds are the versions of TaskRuns stored by tekton results
+var taskRunKinds = []schema.GroupVersionKind{
+	{Group: "tekton.dev", Version: "v1", Kind: "TaskRun"},
+	{Group: "tekton.dev", Version: "v1beta1", Kind: "TaskRun"},
+}
+
+// TaskRuns lists the child TaskRuns of a PipelineRun, in the order they were
+// executed by the pipeline.
+func TaskRuns(c client.Client, pr *unstructured.Unstructured) ([]unstructured.Unstructured, error) {
+	o := &Options{
+		Parent: pr.GetAnnotations()[annotation.Result],
+		ListOptions: metav1.ListOptions{
+			Limit: 100,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: pr.GetNamespace(),
+			OwnerReferences: []metav1.OwnerReference{
+				{UID: pr.GetUID()},
+			},
+		},
+	}
+	o.SetKinds(taskRunKinds)
+
+	var items []unstructured.Unstructured
+	for nextPage := true; nextPage; {
+		lrr, err := Records(c, o)
+		if err != nil {
+			return nil, err
+		}
+		i, err := Decode(lrr.Records)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, i...)
+		o.ListOptions.Continue = lrr.NextPageToken
+		nextPage = lrr.NextPageToken != ""
+	}
+
+	SortTaskRuns(pr, items)
+	return items, nil
+}
+
+// SortTaskRuns orders TaskRuns by start time, with the finally tasks at the end.
+// TaskRuns which didn't start are ordered as defined in the pipeline.
+func SortTaskRuns(pr *unstructured.Unstructured, items []unstructured.Unstructured) {
+	order := map[string]int{}
+	finally := map[string]bool{}
+	for _, section := range []string{"tasks", "finally"} {
+		tasks, _, _ := unstructured.NestedSlice(pr.Object, "status", "pipelineSpec", section)
+		for _, t := range tasks {
+			if m, ok := t.(map[string]interface{}); ok {
+				name, _ := m["name"].(string)
+				order[name] = len(order)
+				finally[name] = section == "finally"
+			}
+		}
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		ti := items[i].GetLabels()[PipelineTaskLabel]
+		tj := items[j].GetLabels()[PipelineTaskLabel]
+		if finally[ti] != finally[tj] {
+			return finally[tj]
+		}
+		si, iok := timestamp(&items[i], "status", "startTime")
+		sj, jok := timestamp(&items[j], "status", "startTime")
+		if iok && jok && si != sj {
+			return si < sj
+		}
+		if iok != jok {
+			return iok
+		}
+		return order[ti] < order[tj]
+	})
+}
@@ -19,6 +19,9 @@ const (
 type Client interface {
 	resultsv1alpha3.LogsClient
 	resultsv1alpha2.ResultsClient
+	// LogsV1alpha2 returns the client for the v1alpha2 Logs API, which is
+	// served by older servers instead of the v1alpha3 Logs API.
+	LogsV1alpha2() resultsv1alpha2.LogsClient
 }
 
 type Config struct {
@@ -20,6 +20,7 @@ import (
 type GRPCClient struct {
 	resultsv1alpha3.LogsClient
 	resultsv1alpha2.ResultsClient
+	logsV1alpha2 resultsv1alpha2.LogsClient
 }
 
 // NewGRPCClient creates a new gRPC client.
@@ -74,9 +75,15 @@ func NewGRPCClient(c *Config) (Client, error) {
 	return &GRPCClient{
 		resultsv1alpha3.NewLogsClient(clientConn),
 		resultsv1alpha2.NewResultsClient(clientConn),
+		resultsv1alpha2.NewLogsClient(clientConn),
 	}, nil
 }
 
+// LogsV1alpha2 returns the client for the v1alpha2 Logs API
+func (c *GRPCClient) LogsV1alpha2() resultsv1alpha2.LogsClient {
+	return c.logsV1alpha2
+}
+
 func (c *Config) ClientTLSConfig() (*tls.Config, error) {
 	tc := &tls.Config{
 		InsecureSkipVerify: c.Transport.TLS.Insecure,
@@ -9,6 +9,8 @@ import (
 	v1alpha3 "github.com/tektoncd/results/proto/v1alpha3/results_go_proto"
 	"google.golang.org/genproto/googleapis/api/httpbody"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/reflect/protoreflect"
@@ -21,7 +23,9 @@ import (
 )
 
 const (
-	pathPrefix = "parents"
+	pathPrefix  = "parents"
+	logsVersion = "v1alpha3"
+	chunkSize   = 32 * 1024
 )
 
 type RESTClient struct {
@@ -79,9 +83,15 @@ func (c *RESTClient) DeleteResult(ctx context.Context, in *v1alpha2.DeleteResult
 	return out, protojson.Unmarshal(b, out)
 }
 
-func (c *RESTClient) CreateResult(_ context.Context, _ *v1alpha2.CreateResultRequest, _ ...grpc.CallOption) (*v1alpha2.Result, error) {
-	//TODO implement me
-	panic("not implemented")
+// CreateResult makes request to create result, the name of the result is
+// kept when set
+func (c *RESTClient) CreateResult(ctx context.Context, in *v1alpha2.CreateResultRequest, _ ...grpc.CallOption) (*v1alpha2.Result, error) {
+	out := &v1alpha2.Result{}
+	b, err := c.send(ctx, http.MethodPost, []string{in.Parent, "results"}, in.GetResult())
+	if err != nil {
+		return nil, err
+	}
+	return out, protojson.Unmarshal(b, out)
 }
 
 func (c *RESTClient) UpdateResult(_ context.Context, _ *v1alpha2.UpdateResultRequest, _ ...grpc.CallOption) (*v1alpha2.Result, error) {
@@ -119,9 +129,15 @@ func (c *RESTClient) DeleteRecord(ctx context.Context, in *v1alpha2.DeleteRecord
 	return out, protojson.Unmarshal(b, out)
 }
 
-func (c *RESTClient) CreateRecord(_ context.Context, _ *v1alpha2.CreateRecordRequest, _ ...grpc.CallOption) (*v1alpha2.Record, error) {
-	//TODO: implement
-	panic("not implemented")
+// CreateRecord makes request to create record, the name of the record is
+// kept when set
+func (c *RESTClient) CreateRecord(ctx context.Context, in *v1alpha2.CreateRecordRequest, _ ...grpc.CallOption) (*v1alpha2.Record, error) {
+	out := &v1alpha2.Record{}
+	b, err := c.send(ctx, http.MethodPost, []string{in.Parent, "records"}, in.GetRecord())
+	if err != nil {
+		return nil, err
+	}
+	return out, protojson.Unmarshal(b, out)
 }
 
 func (c *RESTClient) UpdateRecord(_ context.Context, _ *v1alpha2.UpdateRecordRequest, _ ...grpc.CallOption) (*v1alpha2.Record, error) {
@@ -134,69 +150,114 @@ func (c *RESTClient) GetRecordListSummary(ctx context.Context, _ *v1alpha2.Recor
 	panic("not implemented")
 }
 
+// logsGetLogClient streams the log from the response body in chunks
 type logsGetLogClient struct {
-	log *v1alpha2.Log
+	body io.ReadCloser
 	grpc.ClientStream
 }
 
-func (c logsGetLogClient) Recv() (*httpbody.HttpBody, error) {
-	return &httpbody.HttpBody{
-		ContentType: "text/plain",
-		Data:        c.log.Data,
-	}, nil
+// Recv returns the next chunk of the log. The response body is closed at the
+// end of the stream, or when the context of the request is canceled.
+func (c *logsGetLogClient) Recv() (*httpbody.HttpBody, error) {
+	b := make([]byte, chunkSize)
+	for {
+		n, err := c.body.Read(b)
+		if n > 0 {
+			return &httpbody.HttpBody{
+				ContentType: "text/plain",
+				Data:        b[:n],
+			}, nil
+		}
+		if err != nil {
+			c.body.Close()
+			return nil, err
+		}
+	}
 }
 
+// GetLog makes request to get log from the v1alpha3 Logs API, the log is
+// streamed from the response
 func (c *RESTClient) GetLog(ctx context.Context, in *v1alpha3.GetLogRequest, _ ...grpc.CallOption) (v1alpha2.Logs_GetLogClient, error) {
-	b, err := c.send(ctx, http.MethodGet, []string{in.Name}, in)
+	res, err := c.version(logsVersion).do(ctx, http.MethodGet, []string{in.Name}, in)
 	if err != nil {
 		return nil, err
 	}
-	out := logsGetLogClient{
-		log: &v1alpha2.Log{
-			Data: b,
-		},
+	return &logsGetLogClient{
+		body: res.Body,
+	}, nil
+}
+
+// LogsV1alpha2 returns the client for the v1alpha2 Logs API
+func (c *RESTClient) LogsV1alpha2() v1alpha2.LogsClient {
+	return &restLogsClient{c}
+}
+
+// version returns a client for another version of the API, the API version
+// is the parent of the path prefix of the URL.
+func (c *RESTClient) version(v string) *RESTClient {
+	u := *c.url
+	u.Path = path.Join(path.Dir(path.Dir(u.Path)), v, pathPrefix)
+	return &RESTClient{
+		url:    &u,
+		client: c.client,
+	}
+}
+
+// restLogsClient is the REST client for the v1alpha2 Logs API
+type restLogsClient struct {
+	*RESTClient
+}
+
+// GetLog makes request to get log, the log is streamed from the response
+func (c *restLogsClient) GetLog(ctx context.Context, in *v1alpha2.GetLogRequest, _ ...grpc.CallOption) (v1alpha2.Logs_GetLogClient, error) {
+	res, err := c.do(ctx, http.MethodGet, []string{in.Name}, in)
+	if err != nil {
+		return nil, err
 	}
-	return out, nil
+	return &logsGetLogClient{
+		body: res.Body,
+	}, nil
 }
 
-// ListLogs Functionality not supported now
-func (c *RESTClient) ListLogs(ctx context.Context, in *v1alpha2.ListRecordsRequest, _ ...grpc.CallOption) (*v1alpha2.ListRecordsResponse, error) {
-	//out := &v1alpha2.ListRecordsResponse{}
-	//b, err := c.send(ctx, http.MethodGet, []string{in.Parent, "records"}, in)
-	//if err != nil {
-	//	return nil, err
-	//}
-	//return out, protojson.Unmarshal(b, out)
-	panic("This is not supported")
+// ListLogs makes request to get the list of log records
+func (c *restLogsClient) ListLogs(ctx context.Context, in *v1alpha2.ListRecordsRequest, _ ...grpc.CallOption) (*v1alpha2.ListRecordsResponse, error) {
+	out := &v1alpha2.ListRecordsResponse{}
+	b, err := c.send(ctx, http.MethodGet, []string{in.Parent, "logs"}, in)
+	if err != nil {
+		return nil, err
+	}
+	return out, protojson.Unmarshal(b, out)
 }
 
-// DeleteLog Functionality not supported now
-func (c *RESTClient) DeleteLog(ctx context.Context, in *v1alpha2.DeleteLogRequest, _ ...grpc.CallOption) (*emptypb.Empty, error) {
-	//out := &emptypb.Empty{}
-	//b, err := c.send(ctx, http.MethodDelete, []string{in.Name}, in)
-	//if err != nil {
-	//	return nil, err
-	//}
-	//return out, protojson.Unmarshal(b, out)
-	panic("This is not supported")
+// DeleteLog makes request to delete log
+func (c *restLogsClient) DeleteLog(ctx context.Context, in *v1alpha2.DeleteLogRequest, _ ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := &emptypb.Empty{}
+	b, err := c.send(ctx, http.MethodDelete, []string{in.Name}, in)
+	if err != nil {
+		return nil, err
+	}
+	return out, protojson.Unmarshal(b, out)
 }
 
-func (c *RESTClient) UpdateLog(_ context.Context, _ ...grpc.CallOption) (v1alpha2.Logs_UpdateLogClient, error) {
-	panic("not implemented")
+// UpdateLog is a client streaming method, which is not served by the REST API
+func (c *restLogsClient) UpdateLog(_ context.Context, _ ...grpc.CallOption) (v1alpha2.Logs_UpdateLogClient, error) {
+	return nil, status.Error(codes.Unimplemented, "UpdateLog is not served by the REST API, use the GRPC client type")
 }
 
 func (c *RESTClient) send(ctx context.Context, method string, values []string, in proto.Message) ([]byte, error) {
-	u := c.url.JoinPath(values...)
-	q := u.Query()
+	res, err := c.do(ctx, method, values, in)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	return io.ReadAll(res.Body)
+}
 
-	in.ProtoReflect().Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
-		if fd.JSONName() == "parent" || !fd.HasJSONName() || fd.Kind() == protoreflect.BytesKind {
-			return true
-		}
-		q.Set(fd.JSONName(), v.String())
-		return true
-	})
-	u.RawQuery = q.Encode()
+// do makes the request and returns the response with an unread body, which
+// must be closed by the caller. The message is sent as the body of POST and
+// PUT requests, otherwise as the query.
+func (c *RESTClient) do(ctx context.Context, method string, values []string, in proto.Message) (*http.Response, error) {
+	u := c.url.JoinPath(values...)
 
 	var body io.Reader
 	if method == http.MethodPost || method == http.MethodPut {
@@ -205,6 +266,16 @@ func (c *RESTClient) send(ctx context.Context, method string, values []string, i
 			return nil, err
 		}
 		body = bytes.NewReader(b)
+	} else {
+		q := u.Query()
+		in.ProtoReflect().Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+			if fd.JSONName() == "parent" || !fd.HasJSONName() || fd.Kind() == protoreflect.BytesKind {
+				return true
+			}
+			q.Set(fd.JSONName(), v.String())
+			return true
+		})
+		u.RawQuery = q.Encode()
 	}
 
 	req, err := http.NewRequestWithContext(ctx, method, u.String(), body)
@@ -218,12 +289,12 @@ func (c *RESTClient) send(ctx context.Context, method string, values []string, i
 	}
 
 	if res.StatusCode != http.StatusOK {
+		res.Body.Close()
 		return nil, &runtime.HTTPStatusError{
 			HTTPStatus: res.StatusCode,
 			Err:        errors.New(res.Status),
 		}
 	}
 
-	defer res.Body.Close()
-	return io.ReadAll(res.Body)
+	return res, nil
 }
@@ -21,6 +21,7 @@ import (
 type Config interface {
 	Get() *client.Config
 	GetObject() runtime.Object
+	GetExtension() *Extension
 	Set(data map[string]*string, prompt bool) error
 	Reset() error
 }
@@ -78,6 +79,10 @@ func (c *config) GetObject() runtime.Object {
 	return c.Extension
 }
 
+func (c *config) GetExtension() *Extension {
+	return c.Extension
+}
+
 func (c *config) Reset() error {
 	c.Extension = new(Extension)
 	c.SetVersion()
@@ -22,6 +22,8 @@ type Extension struct {
 	ImpersonateUID        string `json:"act-as-uid,omitempty" group:"auth"`
 	ImpersonateGroups     string `json:"act-as-groups,omitempty" group:"auth"`
 	Token                 string `json:"token,omitempty" group:"auth"`
+	RedactPattern         string `json:"redact-pattern,omitempty" group:"redact"`
+	RedactParams          string `json:"redact-params,omitempty" group:"redact"`
 }
 
 // DeepCopy is an autogenerated deep copy function, copying the receiver, creating a new Extension.